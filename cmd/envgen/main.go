@@ -29,14 +29,33 @@ consistent configuration across different projects.`,
   # Generate ignoring specific types and groups
   envgen gen -c config.yaml -o config.go -t go-env --ignore-types Duration,URL --ignore-groups Database
 
+  # Generate only fields tagged worker, without fields tagged internal
+  envgen gen -c config.yaml -o worker/config.go -t go-env --include-tags worker --exclude-tags internal
+
   # List available standard templates
-  envgen ls`,
+  envgen ls
+
+  # Write the JSON Schema of the configuration format
+  envgen schema -o envgen.schema.json
+
+  # Upgrade a configuration file to the current format version
+  envgen migrate -c config.yaml
+
+  # Create a configuration file from an existing .env file
+  envgen init --from-env .env -o envgen.yaml
+
+  # Create a configuration file from Go structs with env tags
+  envgen import go ./internal/config -o envgen.yaml`,
 	SilenceUsage: true,
 }
 
 func init() {
 	rootCmd.AddCommand(commands.NewGenerateCmd())
 	rootCmd.AddCommand(commands.NewTemplatesCmd())
+	rootCmd.AddCommand(commands.NewSchemaCmd())
+	rootCmd.AddCommand(commands.NewMigrateCmd())
+	rootCmd.AddCommand(commands.NewInitCmd())
+	rootCmd.AddCommand(commands.NewImportCmd())
 }
 
 func main() {
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/safeblock-dev/envgen/internal/user_config"
 	"github.com/safeblock-dev/envgen/pkg/envgen"
 )
 
@@ -17,10 +19,18 @@ const (
 
 var (
 	configPath   string
+	configFormat string
 	outputPath   string
 	templatePath string
 	ignoreTypes  []string
 	ignoreGroups []string
+	onlyGroups   []string
+	ignoreFields []string
+	includeTags  []string
+	excludeTags  []string
+	profile      string
+
+	allowUnknownKeys bool
 )
 
 // NewGenerateCmd creates a new generate command.
@@ -41,11 +51,25 @@ The template can be:
 	}
 
 	// Add flags
-	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to input YAML configuration file")
+	cmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to input YAML, JSON or TOML configuration file")
+	cmd.Flags().StringVar(&configFormat, "config-format", "",
+		"Configuration format (yaml, json, toml), detected by the file extension by default")
 	cmd.Flags().StringVarP(&outputPath, "out", "o", "", "Path to output file")
 	cmd.Flags().StringVarP(&templatePath, "template", "t", "", "Template name, path, or URL")
-	cmd.Flags().StringSliceVar(&ignoreTypes, "ignore-types", nil, "Types to ignore (comma-separated)")
-	cmd.Flags().StringSliceVar(&ignoreGroups, "ignore-groups", nil, "Groups to ignore (comma-separated)")
+	cmd.Flags().StringSliceVar(&ignoreTypes, "ignore-types", nil, "Types to ignore (comma-separated, glob patterns)")
+	cmd.Flags().StringSliceVar(&ignoreGroups, "ignore-groups", nil, "Groups to ignore (comma-separated, glob patterns)")
+	cmd.Flags().StringSliceVar(&onlyGroups, "only-groups", nil,
+		"Groups to generate with the groups they nest (comma-separated, glob patterns)")
+	cmd.Flags().StringSliceVar(&ignoreFields, "ignore-fields", nil,
+		"Fields to ignore as Group.Field (comma-separated, glob patterns)")
+	cmd.Flags().StringSliceVar(&includeTags, "include-tags", nil,
+		"Generate only fields with these tags (comma-separated, glob patterns)")
+	cmd.Flags().StringSliceVar(&excludeTags, "exclude-tags", nil,
+		"Fields with these tags to ignore (comma-separated, glob patterns)")
+	cmd.Flags().StringVar(&profile, "profile", "",
+		"Profile whose default values and required flags are used (e.g. dev, prod)")
+	cmd.Flags().BoolVar(&allowUnknownKeys, "allow-unknown-keys", false,
+		"Ignore unknown keys in the configuration instead of reporting them as errors")
 
 	// Mark required flags
 	_ = cmd.MarkFlagRequired("config")
@@ -66,11 +90,25 @@ func runGenerate(cmd *cobra.Command, _ []string) error {
 	// Generate configuration
 	if err := envgen.Generate(cmd.Context(), envgen.Options{
 		ConfigPath:   configPath,
+		ConfigFormat: configFormat,
 		OutputPath:   outputPath,
 		TemplatePath: templatePath,
 		IgnoreTypes:  ignoreTypes,
 		IgnoreGroups: ignoreGroups,
+		OnlyGroups:   onlyGroups,
+		IgnoreFields: ignoreFields,
+		IncludeTags:  includeTags,
+		ExcludeTags:  excludeTags,
+		Profile:      profile,
+
+		AllowUnknownKeys: allowUnknownKeys,
 	}); err != nil {
+		if diags := user_config.AsDiagnostics(err); diags != nil {
+			printDiagnostics(cmd, diags)
+
+			return fmt.Errorf("failed to generate configuration: %d problem(s) found in %s", len(diags), configPath)
+		}
+
 		return fmt.Errorf("failed to generate configuration: %w", err)
 	}
 
@@ -78,3 +116,18 @@ func runGenerate(cmd *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// printDiagnostics prints configuration problems in the file:line:column: message format.
+// Paths of files inside the working directory are printed relative to it.
+func printDiagnostics(cmd *cobra.Command, diags user_config.Diagnostics) {
+	wd, _ := os.Getwd()
+
+	for _, diag := range diags {
+		pos := diag.Pos
+		if rel, err := filepath.Rel(wd, pos.File); err == nil && wd != "" && !strings.HasPrefix(rel, "..") {
+			pos.File = rel
+		}
+
+		cmd.PrintErrln((&user_config.Diagnostic{Pos: pos, Message: diag.Message}).Error())
+	}
+}
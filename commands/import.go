@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/safeblock-dev/envgen/internal/gostruct"
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+var (
+	importOutputPath string
+	importForce      bool
+)
+
+// NewImportCmd creates a new import command.
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create a configuration file from existing code",
+	}
+
+	cmd.PersistentFlags().StringVarP(&importOutputPath, "out", "o", "envgen.yaml", "Path to output YAML configuration file")
+	cmd.PersistentFlags().BoolVarP(&importForce, "force", "f", false, "Overwrite the output file if it exists")
+
+	cmd.AddCommand(newImportGoCmd())
+
+	return cmd
+}
+
+// newImportGoCmd creates a new import go command.
+func newImportGoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "go <dir>",
+		Short: "Create a configuration file from Go structs with env tags",
+		Long: `Create a YAML configuration file from the Go structs of the package in the directory
+that use the tags of github.com/caarlos0/env (env, envDefault, envPrefix, envSeparator,
+envKeyValSeparator). Every struct with env tags becomes a group, nested structs become
+group fields, doc comments become descriptions. Generating the configuration with the
+go-env template yields equivalent structs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runImportGo,
+	}
+}
+
+func runImportGo(_ *cobra.Command, args []string) error {
+	if err := checkOutputPath(importOutputPath, importForce); err != nil {
+		return err
+	}
+
+	pkg, err := gostruct.ParseDir(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse Go package: %w", err)
+	}
+
+	cfg, err := user_config.FromGoStructs(pkg)
+	if err != nil {
+		return fmt.Errorf("failed to create configuration from %s: %w", args[0], err)
+	}
+
+	for _, w := range cfg.Warnings() {
+		log.Println("warning:", w)
+	}
+
+	header := fmt.Sprintf("Created by envgen import go from package %s, review the groups and descriptions", pkg.Name)
+	if err := writeCreatedConfig(cfg, header, importOutputPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s with %d groups from %s\n", importOutputPath, len(cfg.Groups), args[0])
+
+	return nil
+}
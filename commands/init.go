@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/safeblock-dev/envgen/internal/dotenv"
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+var (
+	initEnvPath    string
+	initOutputPath string
+	initForce      bool
+)
+
+// NewInitCmd creates a new init command.
+func NewInitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a configuration file from an existing .env file",
+		Long: `Create a YAML configuration file from the variables of an existing .env file.
+Variables sharing the first segment of their name are grouped under that prefix,
+types are inferred from the values, comments above the variables become descriptions
+and values become examples. Variables with sensitive names, e.g. DB_PASSWORD, are marked
+as secret and their values are omitted. Review the result before generating code.`,
+		Args: cobra.NoArgs,
+		RunE: runInit,
+	}
+
+	cmd.Flags().StringVar(&initEnvPath, "from-env", "", "Path to input .env file")
+	cmd.Flags().StringVarP(&initOutputPath, "out", "o", "envgen.yaml", "Path to output YAML configuration file")
+	cmd.Flags().BoolVarP(&initForce, "force", "f", false, "Overwrite the output file if it exists")
+
+	_ = cmd.MarkFlagRequired("from-env")
+
+	return cmd
+}
+
+func runInit(_ *cobra.Command, _ []string) error {
+	if err := checkOutputPath(initOutputPath, initForce); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(initEnvPath)
+	if err != nil {
+		return fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	vars, err := dotenv.Parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", initEnvPath, err)
+	}
+
+	cfg, err := user_config.FromEnv(vars)
+	if err != nil {
+		return fmt.Errorf("failed to create configuration from %s: %w", initEnvPath, err)
+	}
+
+	header := fmt.Sprintf("Created by envgen init from %s, review the types and descriptions", filepath.Base(initEnvPath))
+	if err := writeCreatedConfig(cfg, header, initOutputPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s with %d variables from %s\n", initOutputPath, len(vars), initEnvPath)
+
+	return nil
+}
+
+// checkOutputPath checks that the created configuration file does not overwrite an existing file
+// unless forced.
+func checkOutputPath(path string, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", path)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to check output file: %w", err)
+	}
+
+	return nil
+}
+
+// writeCreatedConfig encodes the created configuration with the header comment, writes it
+// to the path and validates it the way generate does. The file is kept if it is invalid,
+// so that the problems can be fixed in place.
+func writeCreatedConfig(cfg *user_config.Config, header, path string) error {
+	out, err := cfg.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	out = append([]byte("# "+header+"\n"), out...)
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, defaultDirPerm); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, out, defaultFilePerm); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	created, err := user_config.New(path)
+	if err == nil {
+		err = created.Validate()
+	}
+
+	if err != nil {
+		return fmt.Errorf("created %s, fix the problems before generating:\n%w", path, err)
+	}
+
+	return nil
+}
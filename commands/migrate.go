@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+var migrateConfigPath string
+
+// NewMigrateCmd creates a new migrate command.
+func NewMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade a configuration file to the current format version",
+		Long: `Upgrade a YAML configuration file to the current version of the configuration format.
+The file is rewritten in place, comments and the order of keys are preserved.
+Included files are not migrated, run the command for each of them.`,
+		Args: cobra.NoArgs,
+		RunE: runMigrate,
+	}
+
+	cmd.Flags().StringVarP(&migrateConfigPath, "config", "c", "", "Path to input YAML configuration file")
+
+	_ = cmd.MarkFlagRequired("config")
+
+	return cmd
+}
+
+func runMigrate(_ *cobra.Command, _ []string) error {
+	if format := user_config.DetectFileFormat(migrateConfigPath); format != user_config.FileFormatYAML {
+		return fmt.Errorf("migrate supports YAML configuration files only, got %s", format)
+	}
+
+	info, err := os.Stat(migrateConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	data, err := os.ReadFile(migrateConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	result, err := user_config.Migrate(data)
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", migrateConfigPath, err)
+	}
+
+	if result.From == result.To {
+		fmt.Printf("%s is already at version %d\n", migrateConfigPath, result.To)
+
+		return nil
+	}
+
+	if err := os.WriteFile(migrateConfigPath, result.Data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	fmt.Printf("Migrated %s from version %d to %d\n", migrateConfigPath, result.From, result.To)
+
+	for _, change := range result.Changes {
+		fmt.Printf("  %s\n", change)
+	}
+
+	return nil
+}
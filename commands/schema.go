@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+// defaultFilePerm is the default permission for created files.
+const defaultFilePerm = 0o644
+
+var schemaOutputPath string
+
+// NewSchemaCmd creates a new schema command.
+func NewSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the configuration format",
+		Long: `Print the JSON Schema describing the configuration format.
+The schema can be used by editors for completion and validation, for example
+with yaml-language-server:
+
+  # yaml-language-server: $schema=./envgen.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: runSchema,
+	}
+
+	cmd.Flags().StringVarP(&schemaOutputPath, "out", "o", "", "Path to output file (default: standard output)")
+
+	return cmd
+}
+
+func runSchema(cmd *cobra.Command, _ []string) error {
+	data, err := user_config.SchemaJSON()
+	if err != nil {
+		return fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	if schemaOutputPath == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+
+		return err
+	}
+
+	if dir := filepath.Dir(schemaOutputPath); dir != "." {
+		if err := os.MkdirAll(dir, defaultDirPerm); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(schemaOutputPath, data, defaultFilePerm); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+
+	fmt.Printf("Generated %s\n", schemaOutputPath)
+
+	return nil
+}
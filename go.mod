@@ -3,6 +3,7 @@ module github.com/safeblock-dev/envgen
 go 1.24.0
 
 require (
+	github.com/pelletier/go-toml v1.9.5
 	github.com/spf13/cobra v1.9.1
 	github.com/stretchr/testify v1.10.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,138 @@
+// Package dotenv parses environment variables from .env files.
+package dotenv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// namePattern matches valid environment variable names.
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Variable is an environment variable declared in a .env file.
+type Variable struct {
+	Name    string // Variable name
+	Value   string // Unquoted value
+	Comment string // Comment lines directly above the variable, joined with spaces
+	Line    int    // Line number of the declaration, starting at 1
+}
+
+// Parse parses the content of a .env file.
+// Supported syntax:
+//
+//	# Comment lines directly above a variable describe it
+//	export NAME=value          # The export prefix is optional
+//	NAME="value with \"escapes\"\n"
+//	NAME='literal value'
+//	NAME=value # Inline comments follow unquoted values after a space
+//
+// Returns an error with the line number for invalid lines and duplicate variables.
+func Parse(data []byte) ([]Variable, error) {
+	var (
+		vars     []Variable
+		comments []string
+	)
+
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case text == "":
+			comments = nil
+
+			continue
+		case strings.HasPrefix(text, "#"):
+			if comment := strings.TrimSpace(strings.TrimLeft(text, "#")); comment != "" {
+				comments = append(comments, comment)
+			}
+
+			continue
+		}
+
+		name, rest, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		name = strings.TrimSpace(name)
+
+		if !ok || !namePattern.MatchString(name) {
+			return nil, fmt.Errorf("line %d: expected NAME=value, got %q", line, text)
+		}
+
+		if first, exists := seen[name]; exists {
+			return nil, fmt.Errorf("line %d: variable %s is already declared at line %d", line, name, first)
+		}
+
+		value, err := parseValue(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		seen[name] = line
+		vars = append(vars, Variable{Name: name, Value: value, Comment: strings.Join(comments, " "), Line: line})
+		comments = nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// parseValue unquotes the value and strips inline comments.
+func parseValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch quote := raw[0]; quote {
+	case '"', '\'':
+		end := closingQuote(raw, quote)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value %s", raw)
+		}
+
+		if rest := strings.TrimSpace(raw[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected %q after quoted value", rest)
+		}
+
+		if quote == '\'' {
+			return raw[1:end], nil
+		}
+
+		return unescape(raw[1:end]), nil
+	}
+
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = raw[:i]
+	}
+
+	return strings.TrimSpace(raw), nil
+}
+
+// closingQuote returns the index of the quote that closes the value.
+// Quotes escaped with a backslash are skipped in double-quoted values.
+// Returns -1 if the value is not terminated.
+func closingQuote(raw string, quote byte) int {
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unescape replaces the escape sequences of double-quoted values.
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`, `\$`, `$`).Replace(s)
+}
@@ -0,0 +1,84 @@
+package dotenv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/dotenv"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		content   string
+		want      []dotenv.Variable
+		wantError string
+	}{
+		{
+			name: "comments and quotes",
+			content: `# Service settings
+
+# HTTP port
+# of the API
+PORT=8080
+export HOST = localhost
+NAME="my \"app\"\n"
+PATTERN='^\d+$' # digits only
+URL=http://localhost#anchor # inline comment
+EMPTY=
+`,
+			want: []dotenv.Variable{
+				{Name: "PORT", Value: "8080", Comment: "HTTP port of the API", Line: 5},
+				{Name: "HOST", Value: "localhost", Line: 6},
+				{Name: "NAME", Value: "my \"app\"\n", Line: 7},
+				{Name: "PATTERN", Value: `^\d+$`, Line: 8},
+				{Name: "URL", Value: "http://localhost#anchor", Line: 9},
+				{Name: "EMPTY", Value: "", Line: 10},
+			},
+		},
+		{
+			name:      "missing value",
+			content:   "PORT\n",
+			wantError: `line 1: expected NAME=value, got "PORT"`,
+		},
+		{
+			name:      "invalid name",
+			content:   "1PORT=8080\n",
+			wantError: `line 1: expected NAME=value, got "1PORT=8080"`,
+		},
+		{
+			name:      "duplicate",
+			content:   "PORT=8080\n\nPORT=9090\n",
+			wantError: "line 3: variable PORT is already declared at line 1",
+		},
+		{
+			name:      "unterminated quote",
+			content:   `NAME="app` + "\n",
+			wantError: `line 1: unterminated quoted value "app`,
+		},
+		{
+			name:      "text after quote",
+			content:   `NAME="app" suffix` + "\n",
+			wantError: `line 1: unexpected "suffix" after quoted value`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars, err := dotenv.Parse([]byte(tt.content))
+			if tt.wantError != "" {
+				require.EqualError(t, err, tt.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, vars)
+		})
+	}
+}
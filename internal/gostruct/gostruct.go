@@ -0,0 +1,279 @@
+// Package gostruct parses struct declarations from Go source files.
+package gostruct
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Package is a Go package with its struct declarations.
+type Package struct {
+	Name    string   // Package name
+	Structs []Struct // Struct types in the order of declaration, files sorted by name
+}
+
+// Struct is a named struct type.
+type Struct struct {
+	Name   string  // Type name
+	Doc    string  // Doc comment joined into one line
+	Fields []Field // Fields in the order of declaration
+	File   string  // Path to the file that declares the type
+	Line   int     // Line number of the declaration, starting at 1
+}
+
+// Field is a field of a struct type.
+type Field struct {
+	Name     string            // Field name, the type name for embedded fields
+	Embedded bool              // Whether the field is embedded
+	Type     string            // Type expression, e.g. []time.Duration
+	Imports  map[string]string // Import paths of the packages referenced by the type, by package name
+	Tag      reflect.StructTag // Struct tag
+	Doc      string            // Doc comment joined into one line
+	Comment  string            // Line comment joined into one line
+	Line     int               // Line number of the declaration, starting at 1
+}
+
+// FindStruct returns the struct type with the name or nil if not found.
+func (p *Package) FindStruct(name string) *Struct {
+	for i := range p.Structs {
+		if p.Structs[i].Name == name {
+			return &p.Structs[i]
+		}
+	}
+
+	return nil
+}
+
+// StructName returns the name of the struct type of the package the field has,
+// following a pointer. Returns an empty string for other types.
+func (p *Package) StructName(field Field) string {
+	name := strings.TrimPrefix(field.Type, "*")
+	if p.FindStruct(name) == nil {
+		return ""
+	}
+
+	return name
+}
+
+// ParseDir parses the struct types declared in the Go files of the directory.
+// Test files are skipped. Returns an error if the directory contains no Go files
+// or files of different packages.
+func ParseDir(dir string) (*Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	slices.Sort(files)
+
+	pkg := new(Package)
+	fset := token.NewFileSet()
+
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		if pkg.Name == "" {
+			pkg.Name = file.Name.Name
+		} else if pkg.Name != file.Name.Name {
+			return nil, fmt.Errorf("%s: package %s differs from package %s of other files", path, file.Name.Name, pkg.Name)
+		}
+
+		pkg.Structs = append(pkg.Structs, parseStructs(fset, file)...)
+	}
+
+	return pkg, nil
+}
+
+// parseStructs returns the struct types declared at the top level of the file.
+func parseStructs(fset *token.FileSet, file *ast.File) []Struct {
+	imports := fileImports(file)
+
+	var structs []Struct
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec) //nolint:forcetypeassert // Specs of type declarations are type specs
+			structType, ok := typeSpec.Type.(*ast.StructType)
+
+			if !ok || typeSpec.TypeParams != nil {
+				continue
+			}
+
+			doc := typeSpec.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+
+			pos := fset.Position(typeSpec.Pos())
+			s := Struct{
+				Name: typeSpec.Name.Name,
+				Doc:  typeDoc(typeSpec.Name.Name, commentText(doc)),
+				File: pos.Filename,
+				Line: pos.Line,
+			}
+
+			for _, field := range structType.Fields.List {
+				s.Fields = append(s.Fields, parseField(fset, field, imports)...)
+			}
+
+			structs = append(structs, s)
+		}
+	}
+
+	return structs
+}
+
+// parseField returns the fields declared by the field list entry, e.g. two fields for A, B int.
+func parseField(fset *token.FileSet, field *ast.Field, imports map[string]string) []Field {
+	f := Field{
+		Type:    types.ExprString(field.Type),
+		Imports: typeImports(field.Type, imports),
+		Doc:     commentText(field.Doc),
+		Comment: commentText(field.Comment),
+		Line:    fset.Position(field.Pos()).Line,
+	}
+
+	if field.Tag != nil {
+		if tag, err := strconv.Unquote(field.Tag.Value); err == nil {
+			f.Tag = reflect.StructTag(tag)
+		}
+	}
+
+	if len(field.Names) == 0 {
+		f.Embedded = true
+		f.Name = f.Type[strings.LastIndex(f.Type, ".")+1:]
+		f.Name = strings.TrimPrefix(f.Name, "*")
+
+		return []Field{f}
+	}
+
+	fields := make([]Field, 0, len(field.Names))
+
+	for _, name := range field.Names {
+		f.Name = name.Name
+		fields = append(fields, f)
+	}
+
+	return fields
+}
+
+// fileImports returns the import paths of the file by package name.
+// The package name of an import without an alias is the last element of its path.
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = path
+	}
+
+	return imports
+}
+
+// typeImports returns the import paths of the packages referenced by the type expression.
+func typeImports(expr ast.Expr, imports map[string]string) map[string]string {
+	var used map[string]string
+
+	ast.Inspect(expr, func(node ast.Node) bool {
+		sel, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			if path, ok := imports[ident.Name]; ok {
+				if used == nil {
+					used = make(map[string]string)
+				}
+
+				used[ident.Name] = path
+			}
+		}
+
+		return false
+	})
+
+	return used
+}
+
+// typeDoc returns the doc comment of the type without the leading type name and verb,
+// e.g. "Server settings" for "Server is server settings". The repeated name written by
+// the go-env template is removed too, e.g. "Server settings" for "Server Server settings".
+// Other doc comments, such as "Server settings", are returned as is.
+func typeDoc(name, doc string) string {
+	rest, ok := strings.CutPrefix(doc, name+" ")
+	if !ok {
+		return doc
+	}
+
+	if strings.HasPrefix(rest, name+" ") {
+		return rest
+	}
+
+	for _, verb := range []string{"is ", "are ", "holds ", "contains "} {
+		if after, ok := strings.CutPrefix(rest, verb); ok && after != "" {
+			return upperFirst(after)
+		}
+	}
+
+	return doc
+}
+
+// upperFirst returns the string with its first letter in upper case.
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// commentText returns the text of the comment group joined into one line.
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
@@ -0,0 +1,131 @@
+package gostruct_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/gostruct"
+)
+
+func TestParseDir(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"b.go": `package config
+
+import (
+	"net/url"
+	tm "time"
+)
+
+// Server holds HTTP server settings
+type Server struct {
+	// Listen port
+	Port int ` + "`" + `env:"PORT" envDefault:"8080"` + "`" + `
+	Timeout tm.Duration // Read timeout
+	Endpoints []*url.URL
+	Host, Path string
+	Base
+}
+`,
+		"a.go": `package config
+
+// Base settings
+type Base struct{}
+
+type (
+	// Names is not a struct
+	Names []string
+
+	// Options Options of the server
+	Options struct{ Debug bool ` + "`" + `env:"DEBUG"` + "`" + ` }
+
+	// Pair of values
+	Pair[T any] struct{ A, B T }
+)
+`,
+		"a_test.go": `package config_test`,
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600))
+	}
+
+	pkg, err := gostruct.ParseDir(tmpDir)
+	require.NoError(t, err)
+	require.Equal(t, &gostruct.Package{
+		Name: "config",
+		Structs: []gostruct.Struct{
+			{Name: "Base", Doc: "Base settings", File: filepath.Join(tmpDir, "a.go"), Line: 4},
+			{
+				Name:   "Options",
+				Doc:    "Options of the server",
+				File:   filepath.Join(tmpDir, "a.go"),
+				Line:   11,
+				Fields: []gostruct.Field{{Name: "Debug", Type: "bool", Tag: reflect.StructTag(`env:"DEBUG"`), Line: 11}},
+			},
+			{
+				Name: "Server",
+				Doc:  "HTTP server settings",
+				File: filepath.Join(tmpDir, "b.go"),
+				Line: 9,
+				Fields: []gostruct.Field{
+					{Name: "Port", Type: "int", Tag: reflect.StructTag(`env:"PORT" envDefault:"8080"`), Doc: "Listen port", Line: 11},
+					{Name: "Timeout", Type: "tm.Duration", Imports: map[string]string{"tm": "time"}, Comment: "Read timeout", Line: 12},
+					{Name: "Endpoints", Type: "[]*url.URL", Imports: map[string]string{"url": "net/url"}, Line: 13},
+					{Name: "Host", Type: "string", Line: 14},
+					{Name: "Path", Type: "string", Line: 14},
+					{Name: "Base", Embedded: true, Type: "Base", Line: 15},
+				},
+			},
+		},
+	}, pkg)
+
+	require.Equal(t, "Base", pkg.StructName(gostruct.Field{Type: "*Base"}))
+	require.Empty(t, pkg.StructName(gostruct.Field{Type: "Names"}))
+}
+
+func TestParseDir_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantError string
+	}{
+		{
+			name:      "no files",
+			files:     map[string]string{"README.md": "# Config"},
+			wantError: "no Go files in",
+		},
+		{
+			name:      "different packages",
+			files:     map[string]string{"a.go": "package a", "b.go": "package b"},
+			wantError: "b.go: package b differs from package a of other files",
+		},
+		{
+			name:      "syntax error",
+			files:     map[string]string{"a.go": "package a\ntype A struct {"},
+			wantError: "a.go:2:16: expected '}', found 'EOF'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600))
+			}
+
+			_, err := gostruct.ParseDir(tmpDir)
+			require.ErrorContains(t, err, tt.wantError)
+		})
+	}
+}
@@ -28,18 +28,26 @@ func ToInt(s string, def ...int) int {
 	return result
 }
 
-// ToBool converts a string to a boolean with an optional default value.
-// Recognizes common boolean string representations:
+// ToBool converts a value to a boolean with an optional default value.
+// Booleans are returned as is, other values are converted to strings and
+// recognized by common boolean string representations:
 // - true: "true", "1", "yes", "on"
 // - false: "false", "0", "no", "off"
 // If the conversion fails, returns the default value (false if not specified).
-func ToBool(s string, def ...bool) bool {
+func ToBool(v any, def ...bool) bool {
 	var defaultVal bool
 	if len(def) > 0 {
 		defaultVal = def[0]
 	}
 
-	switch strings.ToLower(s) {
+	switch val := v.(type) {
+	case bool:
+		return val
+	case nil:
+		return defaultVal
+	}
+
+	switch strings.ToLower(ToString(v)) {
 	case "true", "1", "yes", "on":
 		return true
 	case "false", "0", "no", "off":
@@ -48,3 +56,28 @@ func ToBool(s string, def ...bool) bool {
 		return defaultVal
 	}
 }
+
+// ToList converts a value to a list of strings.
+// Items of lists are converted with ToString, other values are returned as a single item.
+// Returns nil for nil and empty strings.
+func ToList(v any) []string {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []string:
+		return val
+	case []any:
+		list := make([]string, 0, len(val))
+		for _, item := range val {
+			list = append(list, ToString(item))
+		}
+
+		return list
+	case string:
+		if val == "" {
+			return nil
+		}
+	}
+
+	return []string{ToString(v)}
+}
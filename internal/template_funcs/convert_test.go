@@ -93,7 +93,7 @@ func TestToBool(t *testing.T) {
 
 	tests := []struct {
 		name       string
-		input      string
+		input      any
 		defaultVal []bool
 		expected   bool
 	}{
@@ -153,6 +153,28 @@ func TestToBool(t *testing.T) {
 			input:    "",
 			expected: false,
 		},
+		{
+			name:     "true bool",
+			input:    true,
+			expected: true,
+		},
+		{
+			name:       "false bool with default",
+			input:      false,
+			defaultVal: []bool{true},
+			expected:   false,
+		},
+		{
+			name:     "int value",
+			input:    1,
+			expected: true,
+		},
+		{
+			name:       "nil with default",
+			input:      nil,
+			defaultVal: []bool{true},
+			expected:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,3 +186,28 @@ func TestToBool(t *testing.T) {
 		})
 	}
 }
+
+func TestToList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    any
+		expected []string
+	}{
+		{name: "nil", input: nil, expected: nil},
+		{name: "empty string", input: "", expected: nil},
+		{name: "string", input: "file,notEmpty", expected: []string{"file,notEmpty"}},
+		{name: "bool", input: true, expected: []string{"true"}},
+		{name: "strings", input: []string{"a", "b"}, expected: []string{"a", "b"}},
+		{name: "list", input: []any{"file", 1, true}, expected: []string{"file", "1", "true"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, template_funcs.ToList(tt.input))
+		})
+	}
+}
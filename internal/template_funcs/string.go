@@ -1,6 +1,7 @@
 package template_funcs
 
 import (
+	"slices"
 	"strings"
 	"unicode"
 )
@@ -142,6 +143,18 @@ func StringUniq(items []string) []string {
 	return result
 }
 
+// StringSort returns a sorted copy of a slice.
+func StringSort(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+
+	result := slices.Clone(items)
+	slices.Sort(result)
+
+	return result
+}
+
 // StringSlice creates a new slice from the given arguments.
 func StringSlice(args ...string) []string {
 	return args
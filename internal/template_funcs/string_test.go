@@ -190,3 +190,26 @@ func TestStringUniq(t *testing.T) {
 		})
 	}
 }
+
+func TestStringSort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{name: "empty slice", input: nil, expected: nil},
+		{name: "sorted", input: []string{"a", "b", "c"}, expected: []string{"a", "b", "c"}},
+		{name: "unsorted", input: []string{"time", "fmt", "net/url"}, expected: []string{"fmt", "net/url", "time"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := template_funcs.StringSort(test.input)
+			require.Equal(t, test.expected, result)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package user_config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/safeblock-dev/envgen/internal/dotenv"
+	"github.com/safeblock-dev/envgen/internal/template_funcs"
+)
+
+// Library types used by FromEnv for inferred durations and URLs.
+const (
+	bootstrapDuration = "Duration"
+	bootstrapURL      = "URL"
+)
+
+// ungroupedName is the name of the group of variables without a common prefix.
+const ungroupedName = "General"
+
+// sensitiveWords are name segments of variables that hold sensitive values.
+var sensitiveWords = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "CREDENTIALS"}
+
+// FromEnv builds a user_configuration from environment variables declared in a .env file.
+// Variables that share the first segment of their name with another variable are grouped
+// under that prefix, the others are placed in the General group. Types are inferred from the values
+// (bool, int, float64 and the Duration and URL library types, declared with from: library),
+// comments become descriptions and values become examples.
+// Variables with sensitive names are marked as secret and their values are omitted.
+// Returns an error if a variable name cannot be represented by a field name.
+func FromEnv(vars []dotenv.Variable) (*Config, error) {
+	cfg := &Config{Version: CurrentVersion}
+
+	prefixes := make(map[string]int)
+
+	for _, v := range vars {
+		if prefix, rest, ok := strings.Cut(v.Name, "_"); ok && prefix != "" && rest != "" {
+			prefixes[prefix]++
+		}
+	}
+
+	for _, v := range vars {
+		prefix := ""
+		if first, rest, ok := strings.Cut(v.Name, "_"); ok && first != "" && rest != "" && prefixes[first] > 1 {
+			prefix = first + "_"
+		}
+
+		group := cfg.bootstrapGroup(prefix)
+
+		field, err := bootstrapField(v, prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		group.Fields = append(group.Fields, field)
+	}
+
+	for _, name := range []string{bootstrapDuration, bootstrapURL} {
+		if cfg.usesType(name) {
+			cfg.Types = append(cfg.Types, TypeDefinition{Name: name, From: LibrarySource})
+		}
+	}
+
+	return cfg, nil
+}
+
+// bootstrapGroup returns the group of the variables with the prefix, adding it if needed.
+func (c *Config) bootstrapGroup(prefix string) *Group {
+	for i := range c.Groups {
+		if c.Groups[i].Prefix == prefix {
+			return &c.Groups[i]
+		}
+	}
+
+	name := ungroupedName
+	if prefix != "" {
+		name = template_funcs.ToPascalCase(strings.ToLower(strings.TrimSuffix(prefix, "_")))
+	}
+
+	// Names derived from different prefixes may coincide, e.g. GENERAL_ and ungrouped variables
+	for base, i := name, 2; c.FindGroup(name) != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	c.Groups = append(c.Groups, Group{Name: name, Prefix: prefix})
+
+	return &c.Groups[len(c.Groups)-1]
+}
+
+// bootstrapField returns the field of the variable in a group with the prefix.
+func bootstrapField(v dotenv.Variable, prefix string) (Field, error) {
+	rest := strings.TrimPrefix(v.Name, prefix)
+
+	field := Field{
+		Name:        template_funcs.ToPascalCase(strings.ToLower(rest)),
+		Type:        inferType(v.Value),
+		Description: v.Comment,
+		Example:     v.Value,
+	}
+
+	// Names that do not survive the case conversion are kept as written
+	if field.EnvName(prefix) != v.Name {
+		field.Name = rest
+	}
+
+	if field.EnvName(prefix) != v.Name {
+		return Field{}, fmt.Errorf("line %d: cannot derive a field name for variable %s", v.Line, v.Name)
+	}
+
+	if isSensitiveName(v.Name) {
+		field.Secret = true
+		field.Example = ""
+	}
+
+	return field, nil
+}
+
+// inferType returns the field type that fits the value.
+// Returns string if no narrower type fits.
+func inferType(value string) string {
+	if value == "" {
+		return "string"
+	}
+
+	if _, err := strconv.ParseBool(value); err == nil && strings.ContainsAny(value, "eE") {
+		return "bool" // true or false, not 1, 0, t or f
+	}
+
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return "int"
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil && strings.Contains(value, ".") {
+		return "float64"
+	}
+
+	if _, err := time.ParseDuration(value); err == nil {
+		return bootstrapDuration
+	}
+
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" && u.Host != "" {
+		return bootstrapURL
+	}
+
+	return "string"
+}
+
+// isSensitiveName reports whether the variable name suggests a sensitive value,
+// e.g. DB_PASSWORD or STRIPE_API_KEY.
+func isSensitiveName(name string) bool {
+	words := strings.Split(strings.ToUpper(name), "_")
+	if words[len(words)-1] == "KEY" {
+		return true
+	}
+
+	return slices.ContainsFunc(words, func(word string) bool {
+		return slices.Contains(sensitiveWords, word)
+	})
+}
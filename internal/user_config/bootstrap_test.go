@@ -0,0 +1,131 @@
+package user_config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/dotenv"
+	"github.com/safeblock-dev/envgen/internal/user_config"
+	"github.com/safeblock-dev/envgen/pkg/envgen"
+)
+
+const bootstrapEnv = `# Application name
+APP_NAME=shop
+APP_DEBUG=true
+APP_PORT=8080
+APP_TIMEOUT=30s
+
+DB_URL=postgres://localhost:5432/shop
+DB_PASSWORD=secret
+DB_RATIO=0.5
+LOG_LEVEL=info
+API_KEY=key
+`
+
+func TestFromEnv(t *testing.T) {
+	t.Parallel()
+
+	vars, err := dotenv.Parse([]byte(bootstrapEnv))
+	require.NoError(t, err)
+
+	cfg, err := user_config.FromEnv(vars)
+	require.NoError(t, err)
+
+	data, err := cfg.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, `version: 2
+types:
+  - name: Duration
+    from: library
+  - name: URL
+    from: library
+groups:
+  - name: App
+    prefix: APP_
+    fields:
+      - name: Name
+        type: string
+        description: Application name
+        example: shop
+      - name: Debug
+        type: bool
+        example: "true"
+      - name: Port
+        type: int
+        example: "8080"
+      - name: Timeout
+        type: Duration
+        example: 30s
+  - name: Db
+    prefix: DB_
+    fields:
+      - name: Url
+        type: URL
+        example: postgres://localhost:5432/shop
+      - name: Password
+        type: string
+        secret: true
+      - name: Ratio
+        type: float64
+        example: "0.5"
+  - name: General
+    fields:
+      - name: LogLevel
+        type: string
+        example: info
+      - name: ApiKey
+        type: string
+        secret: true
+`, string(data))
+}
+
+func TestFromEnv_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	vars, err := dotenv.Parse([]byte(bootstrapEnv))
+	require.NoError(t, err)
+
+	cfg, err := user_config.FromEnv(vars)
+	require.NoError(t, err)
+
+	data, err := cfg.Marshal()
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"envgen.yaml": string(data)})
+
+	outputPath := filepath.Join(tmpDir, ".env.example")
+	err = envgen.Generate(t.Context(), envgen.Options{
+		ConfigPath:   filepath.Join(tmpDir, "envgen.yaml"),
+		OutputPath:   outputPath,
+		TemplatePath: "../../templates/example",
+	})
+	require.NoError(t, err)
+
+	output, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+
+	generated, err := dotenv.Parse(output)
+	require.NoError(t, err)
+	require.Len(t, generated, len(vars))
+
+	for i, v := range vars {
+		require.Equal(t, v.Name, generated[i].Name)
+
+		if v.Name == "DB_PASSWORD" || v.Name == "API_KEY" {
+			require.Equal(t, "CHANGE_ME", generated[i].Value)
+		} else {
+			require.Equal(t, v.Value, generated[i].Value)
+		}
+	}
+}
+
+func TestFromEnv_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	_, err := user_config.FromEnv([]dotenv.Variable{{Name: "APP__PORT", Value: "1", Line: 3}, {Name: "APP_HOST", Line: 4}})
+	require.EqualError(t, err, "line 3: cannot derive a field name for variable APP__PORT")
+}
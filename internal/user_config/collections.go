@@ -0,0 +1,211 @@
+package user_config
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Separators of list and map values used when a field declares no separators,
+// the defaults of github.com/caarlos0/env.
+const (
+	defaultSeparator         = ","
+	defaultKeyValueSeparator = ":"
+)
+
+// goTagSeparator matches separators declared in the go_tags option,
+// the way to declare them before separator and key_value_separator were added.
+var goTagSeparator = regexp.MustCompile(`(envSeparator|envKeyValSeparator):"([^"]*)"`)
+
+// separators are the separators of elements and of keys and values of a field value.
+type separators struct {
+	list     string
+	keyValue string
+}
+
+// GetSeparator returns the separator of list elements and map entries.
+// Returns the separator declared in go_tags or "," if the field declares no separator.
+func (f *Field) GetSeparator() string {
+	if f.Separator != "" {
+		return f.Separator
+	}
+
+	if separator := f.goTagSeparator("envSeparator"); separator != "" {
+		return separator
+	}
+
+	return defaultSeparator
+}
+
+// GetKeyValueSeparator returns the separator of keys and values of map entries.
+// Returns the separator declared in go_tags or ":" if the field declares no separator.
+func (f *Field) GetKeyValueSeparator() string {
+	if f.KeyValueSeparator != "" {
+		return f.KeyValueSeparator
+	}
+
+	if separator := f.goTagSeparator("envKeyValSeparator"); separator != "" {
+		return separator
+	}
+
+	return defaultKeyValueSeparator
+}
+
+// SeparatorNote returns a human-readable note of the separators declared by a list or map field,
+// e.g. "Map entries separated by ;, keys and values by =".
+// Returns an empty string if the field declares no separators.
+func (c *Config) SeparatorNote(field *Field) string {
+	if field.Separator == "" && field.KeyValueSeparator == "" {
+		return ""
+	}
+
+	if TypeKind(c.ResolveType(field.Type)) == KindMap {
+		return "Map entries separated by " + field.GetSeparator() + ", keys and values by " + field.GetKeyValueSeparator()
+	}
+
+	return "List elements separated by " + field.GetSeparator()
+}
+
+// goTagSeparator returns the separator declared by the tag in the go_tags option.
+func (f *Field) goTagSeparator(tag string) string {
+	for _, m := range goTagSeparator.FindAllStringSubmatch(strings.Join(f.Options.List("go_tags"), " "), -1) {
+		if m[1] == tag {
+			return m[2]
+		}
+	}
+
+	return ""
+}
+
+// separators returns the separators of the field values.
+func (f *Field) separators() separators {
+	return separators{list: f.GetSeparator(), keyValue: f.GetKeyValueSeparator()}
+}
+
+// ElemType returns the element type of a slice type or the value type of a map type.
+// Returns an empty string for other types.
+func ElemType(goType string) string {
+	goType = strings.TrimSpace(goType)
+
+	switch TypeKind(goType) {
+	case KindSlice:
+		return strings.TrimPrefix(goType, "[]")
+	case KindMap:
+		_, value, _ := splitMapType(goType)
+
+		return value
+	default:
+		return ""
+	}
+}
+
+// KeyType returns the key type of a map type.
+// Returns an empty string for other types.
+func KeyType(goType string) string {
+	goType = strings.TrimSpace(goType)
+	if TypeKind(goType) != KindMap {
+		return ""
+	}
+
+	key, _, _ := splitMapType(goType)
+
+	return key
+}
+
+// validateFieldSeparators checks that separators are declared for list and map fields only.
+func (c *Config) validateFieldSeparators(group *Group, field *Field) Diagnostics {
+	var diags Diagnostics
+
+	kind := TypeKind(c.ResolveType(field.Type))
+
+	if field.Separator != "" && kind != KindSlice && kind != KindMap {
+		diags = append(diags, diagnosticf(field.pos.at("separator"),
+			"separator requires a list or map type for field %q in group %q, got %s", field.Name, group.Name, field.Type))
+	}
+
+	if field.KeyValueSeparator != "" && kind != KindMap {
+		diags = append(diags, diagnosticf(field.pos.at("key_value_separator"),
+			"key_value_separator requires a map type for field %q in group %q, got %s", field.Name, group.Name, field.Type))
+	}
+
+	if kind == KindMap && field.GetSeparator() == field.GetKeyValueSeparator() {
+		diags = append(diags, diagnosticf(field.pos.at("key_value_separator"),
+			"separator and key_value_separator of field %q in group %q must differ", field.Name, group.Name))
+	}
+
+	return diags
+}
+
+// decodeCollections decodes list and map default and example values written as YAML sequences
+// and mappings. The node is decoded with the collections replaced, then they are joined
+// with the separators of the decoded field.
+func (f *Field) decodeCollections(node *yaml.Node, decode func(*yaml.Node) error) error {
+	if node.Kind != yaml.MappingNode {
+		return decode(node)
+	}
+
+	collections := make(map[string]*yaml.Node)
+
+	decoded := *node
+	decoded.Content = slices.Clone(node.Content)
+
+	for i := 0; i+1 < len(decoded.Content); i += 2 {
+		key, value := decoded.Content[i].Value, decoded.Content[i+1]
+		if (key != "default" && key != "example") || (value.Kind != yaml.SequenceNode && value.Kind != yaml.MappingNode) {
+			continue
+		}
+
+		collections[key] = value
+		decoded.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Line: value.Line, Column: value.Column}
+	}
+
+	if err := decode(&decoded); err != nil {
+		return err
+	}
+
+	for key, value := range collections {
+		joined, err := joinCollection(value, f.separators())
+		if err != nil {
+			return err
+		}
+
+		if key == "default" {
+			f.Default = joined
+		} else {
+			f.Example = joined
+		}
+	}
+
+	return nil
+}
+
+// joinCollection joins the scalars of a sequence node, or the key and value scalars of a mapping node,
+// into a value with the separators.
+func joinCollection(node *yaml.Node, sep separators) (string, error) {
+	elems := make([]string, 0, len(node.Content))
+
+	for i := 0; i < len(node.Content); i++ {
+		item := node.Content[i]
+		if item.Kind != yaml.ScalarNode {
+			return "", diagnosticf(Position{Line: item.Line, Column: item.Column}, "list and map values must contain scalars only")
+		}
+
+		if node.Kind == yaml.SequenceNode {
+			elems = append(elems, item.Value)
+
+			continue
+		}
+
+		value := node.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return "", diagnosticf(Position{Line: value.Line, Column: value.Column}, "list and map values must contain scalars only")
+		}
+
+		elems = append(elems, item.Value+sep.keyValue+value.Value)
+		i++
+	}
+
+	return strings.Join(elems, sep.list), nil
+}
@@ -0,0 +1,162 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestElemType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		goType   string
+		wantElem string
+		wantKey  string
+	}{
+		{goType: "[]string", wantElem: "string"},
+		{goType: "[]time.Duration", wantElem: "time.Duration"},
+		{goType: "map[string]int", wantElem: "int", wantKey: "string"},
+		{goType: "map[string][]string", wantElem: "[]string", wantKey: "string"},
+		{goType: "string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.wantElem, user_config.ElemType(tt.goType))
+			require.Equal(t, tt.wantKey, user_config.KeyType(tt.goType))
+		})
+	}
+}
+
+func TestFieldSeparators(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		field        user_config.Field
+		wantSep      string
+		wantKeyValue string
+	}{
+		{
+			name:         "defaults",
+			field:        user_config.Field{Name: "Tags", Type: "[]string"},
+			wantSep:      ",",
+			wantKeyValue: ":",
+		},
+		{
+			name:         "declared",
+			field:        user_config.Field{Name: "Labels", Type: "map[string]string", Separator: ";", KeyValueSeparator: "="},
+			wantSep:      ";",
+			wantKeyValue: "=",
+		},
+		{
+			name: "declared in go_tags",
+			field: user_config.Field{
+				Name:    "Labels",
+				Type:    "map[string]string",
+				Options: user_config.Options{"go_tags": `envSeparator:";" envKeyValSeparator:"="`},
+			},
+			wantSep:      ";",
+			wantKeyValue: "=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.wantSep, tt.field.GetSeparator())
+			require.Equal(t, tt.wantKeyValue, tt.field.GetKeyValueSeparator())
+		})
+	}
+}
+
+func TestFieldUnmarshalCollections(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.yaml": `groups:
+  - name: App
+    fields:
+      - name: Origins
+        type: "[]string"
+        default: [a.example.com, b.example.com]
+      - name: Labels
+        type: map[string]int
+        separator: ";"
+        key_value_separator: "="
+        example:
+          a: 1
+          b: 2
+`})
+
+	cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	require.Equal(t, "a.example.com,b.example.com", cfg.Groups[0].Fields[0].Default)
+	require.Equal(t, "a=1;b=2", cfg.Groups[0].Fields[1].Example)
+	require.Equal(t, "Map entries separated by ;, keys and values by =", cfg.SeparatorNote(&cfg.Groups[0].Fields[1]))
+	require.Empty(t, cfg.SeparatorNote(&cfg.Groups[0].Fields[0]))
+}
+
+func TestConfigValidateCollections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		field   user_config.Field
+		wantErr string
+	}{
+		{
+			name:    "separator of scalar field",
+			field:   user_config.Field{Name: "Port", Type: "int", Separator: ";"},
+			wantErr: `separator requires a list or map type for field "Port" in group "App", got int`,
+		},
+		{
+			name:    "key_value_separator of list field",
+			field:   user_config.Field{Name: "Tags", Type: "[]string", KeyValueSeparator: "="},
+			wantErr: `key_value_separator requires a map type for field "Tags" in group "App", got []string`,
+		},
+		{
+			name:    "equal separators",
+			field:   user_config.Field{Name: "Labels", Type: "map[string]string", Separator: ":"},
+			wantErr: `separator and key_value_separator of field "Labels" in group "App" must differ`,
+		},
+		{
+			name:    "list element with separator",
+			field:   user_config.Field{Name: "Ports", Type: "[]int", Separator: ";", Default: "80;http"},
+			wantErr: `invalid default value "80;http" for field "Ports" in group "App": invalid list element "http": expected int`,
+		},
+		{
+			name:    "map entry without key",
+			field:   user_config.Field{Name: "Labels", Type: "map[string]string", Example: "env=prod"},
+			wantErr: `invalid example value "env=prod" for field "Labels" in group "App": invalid map entry "env=prod": expected key:value`,
+		},
+		{
+			name:    "map value",
+			field:   user_config.Field{Name: "Weights", Type: "map[string]float64", Default: "a:0.5,b:half"},
+			wantErr: `invalid default value "a:0.5,b:half" for field "Weights" in group "App": invalid map value "half": expected float64`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &user_config.Config{
+				Groups: []user_config.Group{{Name: "App", Fields: []user_config.Field{tt.field}}},
+			}
+
+			err := cfg.Validate()
+			require.Len(t, user_config.AsDiagnostics(err), 1)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
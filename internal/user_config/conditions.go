@@ -0,0 +1,295 @@
+package user_config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Rules of conditional requirements between fields.
+const (
+	RuleRequiredIf     = "required_if"     // The field is required if the condition holds
+	RuleRequiredUnless = "required_unless" // The field is required unless the condition holds
+	RuleConflictsWith  = "conflicts_with"  // The field must not be set together with the variable
+)
+
+// Operators of conditions.
+const (
+	OperatorSet      = ""   // The variable is set
+	OperatorEqual    = "="  // The variable equals the value
+	OperatorNotEqual = "!=" // The variable does not equal the value
+)
+
+// Condition is a conditional requirement of a field that references another variable.
+// Conditions are written as ENV_NAME, ENV_NAME=value or ENV_NAME!=value,
+// the variable may also be written as Group.Field.
+type Condition struct {
+	Rule     string // required_if, required_unless or conflicts_with
+	Ref      string // Referenced variable as written in the configuration
+	Operator string // Comparison operator, empty if the condition checks that the variable is set
+	Value    string // Compared value
+
+	Field   *Field // Referenced field
+	Group   *Group // Group that declares the referenced field
+	EnvName string // Environment variable name of the referenced field
+
+	// Enforceable reports whether generated code can check the condition: both fields belong
+	// to the same group, the field is a string and the referenced field is a string or,
+	// when compared with a value, a number or bool. Whether a variable is set is checked
+	// with the empty string, since a zero number or false may be set explicitly.
+	Enforceable bool
+	// GoValue is the Go literal of the value, or the empty string literal for conditions that
+	// check that the variable is set. Set for enforceable conditions only.
+	GoValue string
+}
+
+// Expr returns the condition with the environment variable name of the referenced field,
+// e.g. "STORAGE_BACKEND=s3" or "STORAGE_BACKEND is set".
+func (c Condition) Expr() string {
+	if c.Operator == OperatorSet {
+		return c.EnvName + " is set"
+	}
+
+	return c.EnvName + c.Operator + c.Value
+}
+
+// Note returns a human-readable note of the condition, e.g. "Required if STORAGE_BACKEND=s3".
+func (c Condition) Note() string {
+	switch c.Rule {
+	case RuleRequiredIf:
+		return "Required if " + c.Expr()
+	case RuleRequiredUnless:
+		return "Required unless " + c.Expr()
+	default:
+		return "Conflicts with " + c.EnvName
+	}
+}
+
+// HasConditions checks if the field has required_if, required_unless or conflicts_with rules.
+func (f *Field) HasConditions() bool {
+	return f.RequiredIf != "" || f.RequiredUnless != "" || len(f.ConflictsWith) > 0
+}
+
+// Conditions returns the conditional requirements of the field in the order
+// required_if, required_unless, conflicts_with. Conditions that reference unknown variables are skipped.
+func (c *Config) Conditions(field *Field) []Condition {
+	x := c.cachedExpander()
+
+	var conditions []Condition
+
+	for _, rule := range field.rules() {
+		if condition, err := x.condition(rule.name, rule.expr); err == nil {
+			conditions = append(conditions, c.enforce(x, field, condition))
+		}
+	}
+
+	return conditions
+}
+
+// HasEnforceableConditions checks if generated code can check any condition of the group fields.
+func (c *Config) HasEnforceableConditions(group *Group) bool {
+	for i := range group.Fields {
+		for _, condition := range c.Conditions(&group.Fields[i]) {
+			if condition.Enforceable {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// fieldRule is a rule of a conditional requirement with its expression.
+type fieldRule struct {
+	name string
+	expr string
+}
+
+// rules returns the conditional requirements declared by the field.
+func (f *Field) rules() []fieldRule {
+	var rules []fieldRule
+
+	if f.RequiredIf != "" {
+		rules = append(rules, fieldRule{name: RuleRequiredIf, expr: f.RequiredIf})
+	}
+
+	if f.RequiredUnless != "" {
+		rules = append(rules, fieldRule{name: RuleRequiredUnless, expr: f.RequiredUnless})
+	}
+
+	for _, ref := range f.ConflictsWith {
+		rules = append(rules, fieldRule{name: RuleConflictsWith, expr: ref})
+	}
+
+	return rules
+}
+
+// validateFieldConditions checks that the conditional requirements of the field reference
+// other known variables with values valid for their types. Returns a diagnostic for every invalid condition.
+func (c *Config) validateFieldConditions(group *Group, field *Field) Diagnostics {
+	var diags Diagnostics
+
+	x := c.newExpander()
+
+	for _, rule := range field.rules() {
+		pos := field.pos.at(rule.name)
+
+		if rule.name != RuleConflictsWith && (field.Required || field.Default != "") {
+			diags = append(diags, diagnosticf(pos, "%s has no effect on field %q in group %q that is required or has a default value",
+				rule.name, field.Name, group.Name))
+
+			continue
+		}
+
+		condition, err := x.condition(rule.name, rule.expr)
+		if err == nil && condition.Group.Name == group.Name && condition.Field.Name == field.Name {
+			err = fmt.Errorf("field cannot reference itself")
+		}
+
+		if err == nil && condition.Operator != OperatorSet {
+			if valueErr := c.checkValue(condition.Field, condition.Value); valueErr != nil {
+				err = fmt.Errorf("invalid value %q for %s: %w", condition.Value, condition.EnvName, valueErr)
+			}
+		}
+
+		if err != nil {
+			diags = append(diags, diagnosticf(pos, "invalid %s condition %q for field %q in group %q: %s",
+				rule.name, rule.expr, field.Name, group.Name, err))
+		}
+	}
+
+	return diags
+}
+
+// condition parses the expression of the rule and resolves the referenced variable.
+func (x *expander) condition(rule, expr string) (Condition, error) {
+	condition := Condition{Rule: rule, Ref: strings.TrimSpace(expr)}
+
+	if op := strings.Index(expr, OperatorNotEqual); op >= 0 {
+		condition.Ref, condition.Operator, condition.Value = expr[:op], OperatorNotEqual, expr[op+len(OperatorNotEqual):]
+	} else if op := strings.Index(expr, OperatorEqual); op >= 0 {
+		condition.Ref, condition.Operator, condition.Value = expr[:op], OperatorEqual, expr[op+len(OperatorEqual):]
+	}
+
+	condition.Ref = strings.TrimSpace(condition.Ref)
+	condition.Value = strings.TrimSpace(condition.Value)
+
+	switch {
+	case condition.Ref == "":
+		return condition, fmt.Errorf("variable name is required")
+	case rule == RuleConflictsWith && condition.Operator != OperatorSet:
+		return condition, fmt.Errorf("expected a variable name without a value")
+	}
+
+	target, ok := x.refs[condition.Ref]
+	if !ok {
+		if suggestion := closestMatch(condition.Ref, x.names()); suggestion != "" {
+			return condition, fmt.Errorf("unknown variable %s, did you mean %s?", condition.Ref, suggestion)
+		}
+
+		return condition, fmt.Errorf("unknown variable %s", condition.Ref)
+	}
+
+	if x.ambiguous(target, condition.Ref) {
+		return condition, fmt.Errorf("ambiguous variable %s, use one of %s",
+			condition.Ref, strings.Join(x.envs[target], ", "))
+	}
+
+	condition.Field = target
+	condition.Group = x.groups[target]
+	condition.EnvName = x.envName(target, condition.Ref)
+
+	if strings.Contains(condition.EnvName, ".") {
+		// Fields of nested groups that no group embeds have no composed name
+		condition.EnvName = target.EnvName(condition.Group.Prefix)
+	}
+
+	return condition, nil
+}
+
+// enforce decides whether generated code can check the condition of the field and sets its Go value.
+func (c *Config) enforce(x *expander, field *Field, condition Condition) Condition {
+	if x.groups[field] == nil || x.groups[field] != condition.Group {
+		return condition
+	}
+
+	// Numbers and bools cannot tell a zero value set explicitly from an unset variable
+	kind, targetKind := TypeKind(c.ResolveType(field.Type)), TypeKind(c.ResolveType(condition.Field.Type))
+	if kind != KindString || !isScalarKind(targetKind) || (condition.Operator == OperatorSet && targetKind != KindString) {
+		return condition
+	}
+
+	switch {
+	case condition.Operator == OperatorSet:
+		condition.GoValue = `""`
+	case targetKind == KindString:
+		condition.GoValue = strconv.Quote(condition.Value)
+	case targetKind == KindBool:
+		value, err := strconv.ParseBool(condition.Value)
+		if err != nil {
+			return condition
+		}
+
+		condition.GoValue = strconv.FormatBool(value)
+	default:
+		value, ok := numberLiteral(condition.Value, targetKind, bitSize(c.ResolveType(condition.Field.Type)))
+		if !ok {
+			return condition
+		}
+
+		condition.GoValue = value
+	}
+
+	condition.Enforceable = true
+
+	return condition
+}
+
+// numberLiteral returns the value of the numeric kind as a Go literal in canonical form,
+// e.g. 8 for 08, which Go would read as an invalid octal literal.
+// Returns false if the value cannot be parsed as the kind.
+func numberLiteral(value, kind string, bits int) (string, bool) {
+	if bits == 0 {
+		bits = 64
+	}
+
+	switch kind {
+	case KindInt:
+		n, err := strconv.ParseInt(value, 10, bits)
+
+		return strconv.FormatInt(n, 10), err == nil
+	case KindUint:
+		n, err := strconv.ParseUint(value, 10, bits)
+
+		return strconv.FormatUint(n, 10), err == nil
+	case KindFloat:
+		n, err := strconv.ParseFloat(value, bits)
+
+		return strconv.FormatFloat(n, 'g', -1, bits), err == nil
+	default:
+		return "", false
+	}
+}
+
+// names returns the sorted names of all referenceable variables.
+func (x *expander) names() []string {
+	names := make([]string, 0, len(x.refs))
+	for name := range x.refs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// isScalarKind checks if values of the kind can be compared with Go literals.
+func isScalarKind(kind string) bool {
+	switch kind {
+	case KindInt, KindUint, KindFloat, KindBool, KindString:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,197 @@
+package user_config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestConfigConditions(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Groups: []user_config.Group{
+			{
+				Name:   "Storage",
+				Prefix: "STORAGE_",
+				Fields: []user_config.Field{
+					{Name: "Backend", Type: "string", Default: "local"},
+					{Name: "AccessKey", Type: "string", RequiredIf: "STORAGE_BACKEND=s3"},
+					{Name: "Path", Type: "string", RequiredUnless: "Storage.Backend = s3", ConflictsWith: []string{"CACHE_URL"}},
+					{Name: "Retries", Type: "int", RequiredIf: "STORAGE_BACKEND!=local"},
+				},
+			},
+			{
+				Name:   "Cache",
+				Prefix: "CACHE_",
+				Fields: []user_config.Field{{Name: "URL", Type: "string"}},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	storage := &cfg.Groups[0]
+	backend := &storage.Fields[0]
+
+	accessKey := cfg.Conditions(&storage.Fields[1])
+	require.Len(t, accessKey, 1)
+	require.Equal(t, user_config.RuleRequiredIf, accessKey[0].Rule)
+	require.Equal(t, "STORAGE_BACKEND", accessKey[0].EnvName)
+	require.Equal(t, user_config.OperatorEqual, accessKey[0].Operator)
+	require.Equal(t, "s3", accessKey[0].Value)
+	require.Same(t, backend, accessKey[0].Field)
+	require.Same(t, storage, accessKey[0].Group)
+	require.True(t, accessKey[0].Enforceable)
+	require.Equal(t, `"s3"`, accessKey[0].GoValue)
+	require.Equal(t, "Required if STORAGE_BACKEND=s3", accessKey[0].Note())
+
+	path := cfg.Conditions(&storage.Fields[2])
+	require.Len(t, path, 2)
+	require.Equal(t, "Required unless STORAGE_BACKEND=s3", path[0].Note())
+	require.True(t, path[0].Enforceable)
+	require.Equal(t, "Conflicts with CACHE_URL", path[1].Note())
+	require.Equal(t, "CACHE_URL is set", path[1].Expr())
+	require.False(t, path[1].Enforceable, "fields of other groups are not enforceable")
+
+	retries := cfg.Conditions(&storage.Fields[3])
+	require.Equal(t, "Required if STORAGE_BACKEND!=local", retries[0].Note())
+	require.False(t, retries[0].Enforceable, "zero numbers may be set explicitly")
+
+	require.True(t, cfg.HasEnforceableConditions(storage))
+	require.False(t, cfg.HasEnforceableConditions(&cfg.Groups[1]))
+}
+
+func TestConfigConditions_SetChecks(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Groups: []user_config.Group{{
+			Name:   "App",
+			Prefix: "APP_",
+			Fields: []user_config.Field{
+				{Name: "Debug", Type: "bool"},
+				{Name: "Retries", Type: "int"},
+				{Name: "Socket", Type: "string"},
+				{Name: "Profiler", Type: "string", RequiredIf: "APP_DEBUG"},
+				{Name: "Backoff", Type: "string", RequiredIf: "APP_RETRIES!=0"},
+				{Name: "Host", Type: "string", ConflictsWith: []string{"APP_SOCKET", "APP_DEBUG"}},
+				{Name: "Verbose", Type: "bool", ConflictsWith: []string{"APP_SOCKET"}},
+			},
+		}},
+	}
+	require.NoError(t, cfg.Validate())
+
+	tests := []struct {
+		name        string
+		field       int
+		enforceable []bool
+	}{
+		{name: "set check of bool", field: 3, enforceable: []bool{false}},
+		{name: "value of int", field: 4, enforceable: []bool{true}},
+		{name: "set checks of string and bool", field: 5, enforceable: []bool{true, false}},
+		{name: "bool field", field: 6, enforceable: []bool{false}},
+	}
+
+	// Conditions builds the references of the configuration on first use, so the cases share it sequentially
+	for _, tt := range tests {
+		var enforceable []bool
+		for _, condition := range cfg.Conditions(&cfg.Groups[0].Fields[tt.field]) {
+			enforceable = append(enforceable, condition.Enforceable)
+		}
+
+		require.Equal(t, tt.enforceable, enforceable, tt.name)
+	}
+}
+
+func TestConfigConditions_NumberValues(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Groups: []user_config.Group{{
+			Name:   "App",
+			Prefix: "APP_",
+			Fields: []user_config.Field{
+				{Name: "Workers", Type: "int"},
+				{Name: "Ratio", Type: "float64"},
+				{Name: "Queue", Type: "string", RequiredIf: "APP_WORKERS=08"},
+				{Name: "Sampler", Type: "string", RequiredIf: "APP_RATIO=0.50"},
+			},
+		}},
+	}
+	require.NoError(t, cfg.Validate())
+
+	queue := cfg.Conditions(&cfg.Groups[0].Fields[2])
+	require.True(t, queue[0].Enforceable)
+	require.Equal(t, "8", queue[0].GoValue)
+	require.Equal(t, "Required if APP_WORKERS=08", queue[0].Note())
+
+	sampler := cfg.Conditions(&cfg.Groups[0].Fields[3])
+	require.True(t, sampler[0].Enforceable)
+	require.Equal(t, "0.5", sampler[0].GoValue)
+}
+
+func TestConfigValidateConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fields  []user_config.Field
+		wantErr string
+	}{
+		{
+			name: "unknown variable",
+			fields: []user_config.Field{
+				{Name: "Backend", Type: "string"},
+				{Name: "Key", Type: "string", RequiredIf: "APP_BACKEDN=s3"},
+			},
+			wantErr: `invalid required_if condition "APP_BACKEDN=s3" for field "Key" in group "App": unknown variable APP_BACKEDN, did you mean APP_BACKEND?`,
+		},
+		{
+			name: "self reference",
+			fields: []user_config.Field{
+				{Name: "Key", Type: "string", ConflictsWith: []string{"App.Key"}},
+			},
+			wantErr: `invalid conflicts_with condition "App.Key" for field "Key" in group "App": field cannot reference itself`,
+		},
+		{
+			name: "invalid value",
+			fields: []user_config.Field{
+				{Name: "Workers", Type: "int"},
+				{Name: "Queue", Type: "string", RequiredUnless: "APP_WORKERS=many"},
+			},
+			wantErr: `invalid required_unless condition "APP_WORKERS=many" for field "Queue" in group "App": invalid value "many" for APP_WORKERS: expected int`,
+		},
+		{
+			name: "value in conflicts_with",
+			fields: []user_config.Field{
+				{Name: "Socket", Type: "string"},
+				{Name: "Host", Type: "string", ConflictsWith: []string{"APP_SOCKET=/tmp/app.sock"}},
+			},
+			wantErr: `invalid conflicts_with condition "APP_SOCKET=/tmp/app.sock" for field "Host" in group "App": expected a variable name without a value`,
+		},
+		{
+			name: "required field",
+			fields: []user_config.Field{
+				{Name: "Socket", Type: "string"},
+				{Name: "Host", Type: "string", Required: true, RequiredIf: "APP_SOCKET"},
+			},
+			wantErr: `required_if has no effect on field "Host" in group "App" that is required or has a default value`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &user_config.Config{
+				Groups: []user_config.Group{{Name: "App", Prefix: "APP_", Fields: tt.fields}},
+			}
+
+			err := cfg.Validate()
+			require.Len(t, user_config.AsDiagnostics(err), 1)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
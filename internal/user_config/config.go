@@ -1,19 +1,22 @@
 package user_config
 
 import (
-	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
-
-	"gopkg.in/yaml.v3"
+	"slices"
 )
 
 // Config represents the complete generation user_configuration.
 // Example:
 //
+//	version: 2                  # Optional: Version of the configuration format
+//	include:                    # Optional: Shared configuration fragments
+//	  - shared/postgres.yaml    # Path relative to this file
 //	options:                    # Optional: Template-specific options
 //	  go_package: user_config       # Optional: Go package name
+//	profiles: [dev, prod]       # Optional: Environment profiles with field overrides
 //	types:                     # Optional: Type definitions
 //	  - name: LogLevel        # Required: Type name for referencing in fields
 //	    type: zerolog.Level   # Required: Type definition
@@ -23,114 +26,268 @@ import (
 //	      - name: log_level   # Required: Field name
 //	        type: LogLevel    # Required: Field type
 type Config struct {
-	Options map[string]string `yaml:"options"` // Optional: Template-specific options
-	Types   []TypeDefinition  `yaml:"types"`   // Optional: Type definitions
-	Groups  []Group           `yaml:"groups"`  // Required: At least one group must be defined
+	Version  int              `yaml:"version"`  // Optional: Version of the configuration format, 1 if not set
+	Include  []string         `yaml:"include"`  // Optional: Files whose types, groups and options are merged in
+	Options  Options          `yaml:"options"`  // Optional: Template-specific options
+	Profiles []string         `yaml:"profiles"` // Optional: Environment profiles, e.g. dev, staging, prod
+	Types    []TypeDefinition `yaml:"types"`    // Optional: Type definitions
+	Groups   []Group          `yaml:"groups"`   // Required: At least one group must be defined
+
+	path     string      `yaml:"-"` // Path to user_configuration file (not serialized)
+	profile  string      `yaml:"-"` // Name of the applied profile (not serialized)
+	pos      positions   `yaml:"-"` // Locations of the top-level values (not serialized)
+	warnings Diagnostics `yaml:"-"` // Non-fatal problems found during validation (not serialized)
+	expander *expander   `yaml:"-"` // References of the template functions, built on first use (not serialized)
+}
 
-	path string `yaml:"-"` // Path to user_configuration file (not serialized)
+// LoadOptions controls how user_configuration files are loaded.
+type LoadOptions struct {
+	// AllowUnknownKeys disables the check for keys that are not part of the format.
+	// Unknown keys are silently ignored, which allows newer configuration files
+	// to be used with older envgen versions.
+	AllowUnknownKeys bool
+
+	// Format overrides the format of the loaded file.
+	// If empty, the format is detected by the file extension.
+	// Included files are always detected by their extension.
+	Format FileFormat
 }
 
-// New loads and parses user_configuration from file.
-// Returns an error if the file cannot be read or parsed.
+// New loads and parses user_configuration from file with default load options.
+// Files listed in the include section are loaded and merged into the result.
+// Returns an error if any file cannot be read, parsed or merged,
+// or if any file contains keys that are not part of the user_configuration format.
 func New(path string) (*Config, error) {
+	return NewWithOptions(path, LoadOptions{})
+}
+
+// NewWithOptions loads and parses user_configuration from file with the specified load options.
+func NewWithOptions(path string, opts LoadOptions) (*Config, error) {
 	path, err := filepath.Abs(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
+	cfg, err := parseFile(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := newIncludeResolver(path, opts).resolve(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// parseFile reads a single user_configuration file without resolving includes.
+// The file format is taken from the load options or detected by the file extension.
+// Locations of types, groups and fields are recorded for diagnostics.
+func parseFile(path string, opts LoadOptions) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read user_config file: %w", err)
 	}
 
+	format := opts.Format
+	if format == "" {
+		format = DetectFileFormat(path)
+	}
+
 	var cfg Config
 	cfg.path = path
+	cfg.pos.node = Position{File: path, Line: 1, Column: 1}
 
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse user_config file: %w", err)
+	node, err := parseNode(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse user_config file: %w", parseDiagnostics(path, err))
 	}
 
-	return &cfg, nil
-}
+	if node != nil {
+		if err := node.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse user_config file: %w", parseDiagnostics(path, err))
+		}
 
-// FilterTypes removes ignored types from the user_configuration.
-// If ignoreTypes is empty, no filtering is performed.
-func (c *Config) FilterTypes(ignoreTypes []string) {
-	if len(ignoreTypes) == 0 {
-		return
+		cfg.pos = newPositions(node)
+		cfg.pos.setFile(path)
 	}
 
-	ignoreSet := make(map[string]struct{}, len(ignoreTypes))
-	for _, typeName := range ignoreTypes {
-		ignoreSet[typeName] = struct{}{}
+	if diag := cfg.checkVersion(); diag != nil {
+		return nil, fmt.Errorf("failed to parse user_config file: %w", Diagnostics{diag})
 	}
 
-	filtered := make([]TypeDefinition, 0, len(c.Types))
+	for i := range cfg.Types {
+		cfg.Types[i].setFile(path)
+	}
+
+	for i := range cfg.Groups {
+		cfg.Groups[i].setFile(path)
+	}
 
-	for _, t := range c.Types {
-		if _, ignored := ignoreSet[t.Name]; !ignored {
-			filtered = append(filtered, t)
+	if !opts.AllowUnknownKeys {
+		if diags := cfg.checkUnknownKeys(); len(diags) > 0 {
+			return nil, fmt.Errorf("failed to parse user_config file: %w", diags)
 		}
 	}
 
-	c.Types = filtered
+	return &cfg, nil
+}
+
+// FilterTypes removes the types matching the glob patterns, e.g. Duration or *URL.
+// If ignoreTypes is empty, no filtering is performed.
+func (c *Config) FilterTypes(ignoreTypes []string) {
+	if len(ignoreTypes) == 0 {
+		return
+	}
+
+	c.Types = slices.DeleteFunc(c.Types, func(t TypeDefinition) bool { return matchAny(ignoreTypes, t.Name) })
 }
 
-// FilterGroups removes ignored groups from the user_configuration.
+// FilterGroups removes the groups matching the glob patterns, e.g. Database or *Config,
+// together with the fields nesting them.
 // If ignoreGroups is empty, no filtering is performed.
 func (c *Config) FilterGroups(ignoreGroups []string) {
 	if len(ignoreGroups) == 0 {
 		return
 	}
 
-	ignoreSet := make(map[string]struct{}, len(ignoreGroups))
-	for _, groupName := range ignoreGroups {
-		ignoreSet[groupName] = struct{}{}
+	counts, before := c.fieldCounts(), c.newExpander()
+	c.Groups = slices.DeleteFunc(c.Groups, func(g Group) bool { return matchAny(ignoreGroups, g.Name) })
+
+	c.prune(counts)
+	c.warnFilteredReferences(before)
+}
+
+// Validate validates the user_configuration.
+// Returns an error if required fields are missing, if any type or group is invalid,
+// if type or group names are not unique, or if a field refers to a misspelled type.
+// Default and example values are checked against the field types.
+// All problems are reported at once as Diagnostics pointing at their location in the file,
+// non-fatal problems are available through Warnings.
+func (c *Config) Validate() error {
+	if c.Options == nil {
+		c.Options = make(Options)
+	}
+
+	c.warnings = nil
+
+	if len(c.Groups) == 0 {
+		return Diagnostics{diagnosticf(c.pos.at("groups"), "at least one group is required")}
 	}
 
-	filtered := make([]Group, 0, len(c.Groups))
+	var diags Diagnostics
+
+	diags = append(diags, c.validateTypes()...)
+	diags = append(diags, c.validateGroupNames()...)
+	diags = append(diags, c.validateProfiles()...)
+	diags = append(diags, c.expandValues()...)
+
+	for _, group := range c.Groups {
+		diags = append(diags, group.validate()...)
+
+		for _, field := range group.Fields {
+			// Semantic checks are meaningless for fields with missing attributes
+			if len(field.validate()) > 0 {
+				continue
+			}
+
+			if field.Group != "" {
+				if diag := c.validateGroupRef(&group, &field); diag != nil {
+					diags = append(diags, diag)
+				}
 
-	for _, g := range c.Groups {
-		if _, ignored := ignoreSet[g.Name]; !ignored {
-			filtered = append(filtered, g)
+				continue
+			}
+
+			if diag := c.validateFieldType(&group, &field); diag != nil {
+				diags = append(diags, diag)
+
+				continue
+			}
+
+			goType := c.ResolveType(field.Type)
+			if err := field.Constraints.ValidateKind(TypeKind(goType), bitSize(goType)); err != nil {
+				diags = append(diags, diagnosticf(field.pos.at("validate"),
+					"invalid validation rules for field %q in group %q: %s", field.Name, group.Name, err))
+
+				continue
+			}
+
+			diags = append(diags, c.validateFieldSeparators(&group, &field)...)
+			diags = append(diags, c.validateFieldConditions(&group, &field)...)
+			diags = append(diags, c.validateFieldProfiles(&group, &field)...)
+			diags = append(diags, c.validateFieldValues(&group, &field)...)
 		}
 	}
 
-	c.Groups = filtered
+	diags = append(diags, c.validateProfileValues()...)
+	diags = append(diags, c.validateGroupCycles()...)
+	diags = append(diags, c.validateEnvNames()...)
+
+	c.warnUnusedTypes()
+	c.warnExternalReferences()
+	c.warnOutdatedVersion()
+
+	diags.sort()
+	c.warnings.sort()
+
+	return diags.Err()
 }
 
-// Validate validates the user_configuration.
-// Returns an error if required fields are missing or if any group is invalid.
-func (c *Config) Validate() error {
-	if len(c.Groups) == 0 {
-		return errors.New("at least one group is required")
-	}
+// Warnings returns non-fatal problems found by the last call to Validate
+// and references to variables removed by the filters applied since.
+func (c *Config) Warnings() Diagnostics {
+	return c.warnings
+}
 
-	if c.Options == nil {
-		c.Options = make(map[string]string)
+// HasConstraints checks if any field of the configuration has validation rules.
+func (c *Config) HasConstraints() bool {
+	for _, group := range c.Groups {
+		if group.HasConstraints() {
+			return true
+		}
 	}
 
-	for i, group := range c.Groups {
-		if err := group.Validate(); err != nil {
-			return fmt.Errorf("invalid group %d: %w", i, err)
+	return false
+}
+
+// HasSecrets checks if any field of the configuration is marked as secret.
+func (c *Config) HasSecrets() bool {
+	for _, group := range c.Groups {
+		if group.HasSecrets() {
+			return true
 		}
 	}
 
-	return nil
+	return false
 }
 
-// GetOptions returns the template-specific options.
-func (c *Config) GetOptions() map[string]string {
-	if c.Options == nil {
-		return nil
+// HasEnums checks if any type of the configuration, including the types taken from the library, is an enum.
+func (c *Config) HasEnums() bool {
+	types := c.AllTypes()
+	for i := range types {
+		if types[i].IsEnum() {
+			return true
+		}
 	}
 
-	options := make(map[string]string, len(c.Options))
-	for k, v := range c.Options {
-		options[k] = v
+	return false
+}
+
+// HasRenamedFields checks if any variable of the configuration has previous environment variable names.
+func (c *Config) HasRenamedFields() bool {
+	for i := range c.Groups {
+		if c.HasRenamedVariables(&c.Groups[i]) {
+			return true
+		}
 	}
 
-	return options
+	return false
+}
+
+// GetOptions returns the template-specific options.
+func (c *Config) GetOptions() Options {
+	return maps.Clone(c.Options)
 }
 
 // GetTypes returns the type definitions.
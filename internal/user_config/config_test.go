@@ -76,7 +76,7 @@ func TestGetOptions(t *testing.T) {
 	t.Parallel()
 
 	cfg := &user_config.Config{
-		Options: map[string]string{
+		Options: user_config.Options{
 			"key": "value",
 		},
 	}
@@ -306,6 +306,48 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "validation rules on custom string type",
+			cfg: &user_config.Config{
+				Types: []user_config.TypeDefinition{
+					{Name: "Level", Type: "string"},
+				},
+				Groups: []user_config.Group{
+					{
+						Name: "app",
+						Fields: []user_config.Field{
+							{
+								Name: "level",
+								Type: "Level",
+								Constraints: &user_config.Constraints{
+									Format:  "port",
+									Pattern: "^[a-z]+$",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "validation rule on incompatible type",
+			cfg: &user_config.Config{
+				Groups: []user_config.Group{
+					{
+						Name: "app",
+						Fields: []user_config.Field{
+							{
+								Name:        "port",
+								Type:        "int",
+								Constraints: &user_config.Constraints{Pattern: "^[0-9]+$"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "nil options",
 			cfg: &user_config.Config{
@@ -0,0 +1,370 @@
+package user_config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Value formats supported by the format rule.
+const (
+	FormatURL      = "url"      // Absolute URL
+	FormatEmail    = "email"    // Email address
+	FormatHostname = "hostname" // Host name as defined by RFC 1123
+	FormatPort     = "port"     // TCP/UDP port number (0-65535)
+	FormatDuration = "duration" // Duration accepted by time.ParseDuration
+)
+
+// portBitSize is the smallest bit size of integer types that hold every port number.
+const portBitSize = 17
+
+// Constraints describes declarative validation rules of a field.
+// Example:
+//
+//	validate:
+//	  min: 1                      # Optional: Minimum numeric value
+//	  max: 65535                  # Optional: Maximum numeric value
+//	  min_len: 3                  # Optional: Minimum length of a string, list or map
+//	  max_len: 64                 # Optional: Maximum length of a string, list or map
+//	  pattern: "^[a-z]+$"         # Optional: Regular expression the value must match
+//	  values: [debug, info, warn] # Optional: Allowed values
+//	  format: hostname            # Optional: Value format (url, email, hostname, port, duration)
+type Constraints struct {
+	Min     *float64 `yaml:"min"`     // Optional: Minimum numeric value
+	Max     *float64 `yaml:"max"`     // Optional: Maximum numeric value
+	MinLen  *int     `yaml:"min_len"` // Optional: Minimum length of a string, list or map
+	MaxLen  *int     `yaml:"max_len"` // Optional: Maximum length of a string, list or map
+	Pattern string   `yaml:"pattern"` // Optional: Regular expression the value must match
+	Values  []string `yaml:"values"`  // Optional: Allowed values
+	Format  string   `yaml:"format"`  // Optional: Value format (url, email, hostname, port, duration)
+
+	pos positions `yaml:"-"` // Locations of the rules (not serialized)
+}
+
+// UnmarshalYAML decodes the validation rules and records their location in the file.
+func (c *Constraints) UnmarshalYAML(node *yaml.Node) error {
+	type plain Constraints
+	if err := node.Decode((*plain)(c)); err != nil {
+		return err
+	}
+
+	c.pos = newPositions(node)
+
+	return nil
+}
+
+// hostnamePattern matches host names as defined by RFC 1123.
+var hostnamePattern = regexp.MustCompile(
+	`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`,
+)
+
+// constraintKinds lists the type kinds every rule can be applied to.
+var constraintKinds = map[string][]string{
+	"min":     {KindInt, KindUint, KindFloat},
+	"max":     {KindInt, KindUint, KindFloat},
+	"min_len": {KindString, KindSlice, KindMap},
+	"max_len": {KindString, KindSlice, KindMap},
+	"pattern": {KindString},
+	"values":  {KindString, KindInt, KindUint, KindFloat, KindBool},
+}
+
+// formatKinds lists the type kinds every format can be applied to.
+var formatKinds = map[string][]string{
+	FormatURL:      {KindString},
+	FormatEmail:    {KindString},
+	FormatHostname: {KindString},
+	FormatPort:     {KindString, KindInt, KindUint},
+	FormatDuration: {KindString},
+}
+
+// IsEmpty checks if no rule is defined.
+func (c *Constraints) IsEmpty() bool {
+	return c == nil || (len(c.names()) == 0 && c.Format == "")
+}
+
+// GetMin returns the minimum numeric value as a string.
+// Returns an empty string if the rule is not defined.
+func (c *Constraints) GetMin() string {
+	if c == nil || c.Min == nil {
+		return ""
+	}
+
+	return formatBound(*c.Min)
+}
+
+// GetMax returns the maximum numeric value as a string.
+// Returns an empty string if the rule is not defined.
+func (c *Constraints) GetMax() string {
+	if c == nil || c.Max == nil {
+		return ""
+	}
+
+	return formatBound(*c.Max)
+}
+
+// Rules returns a human-readable description of every defined rule.
+func (c *Constraints) Rules() []string {
+	if c == nil {
+		return nil
+	}
+
+	var rules []string
+
+	if c.Min != nil {
+		rules = append(rules, "min: "+c.GetMin())
+	}
+
+	if c.Max != nil {
+		rules = append(rules, "max: "+c.GetMax())
+	}
+
+	if c.MinLen != nil {
+		rules = append(rules, "min_len: "+strconv.Itoa(*c.MinLen))
+	}
+
+	if c.MaxLen != nil {
+		rules = append(rules, "max_len: "+strconv.Itoa(*c.MaxLen))
+	}
+
+	if c.Pattern != "" {
+		rules = append(rules, "pattern: "+c.Pattern)
+	}
+
+	if len(c.Values) > 0 {
+		rules = append(rules, "values: "+strings.Join(c.Values, ", "))
+	}
+
+	if c.Format != "" {
+		rules = append(rules, "format: "+c.Format)
+	}
+
+	return rules
+}
+
+// Validate checks that the rules are consistent with each other.
+// Returns an error if a rule is malformed.
+func (c *Constraints) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return fmt.Errorf("min %s is greater than max %s", c.GetMin(), c.GetMax())
+	}
+
+	if (c.MinLen != nil && *c.MinLen < 0) || (c.MaxLen != nil && *c.MaxLen < 0) {
+		return errors.New("min_len and max_len must not be negative")
+	}
+
+	if c.MinLen != nil && c.MaxLen != nil && *c.MinLen > *c.MaxLen {
+		return fmt.Errorf("min_len %d is greater than max_len %d", *c.MinLen, *c.MaxLen)
+	}
+
+	if c.Pattern != "" {
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", c.Pattern, err)
+		}
+	}
+
+	if c.Format != "" {
+		if _, ok := formatKinds[c.Format]; !ok {
+			return fmt.Errorf("unknown format %q (supported: %s, %s, %s, %s, %s)",
+				c.Format, FormatURL, FormatEmail, FormatHostname, FormatPort, FormatDuration)
+		}
+	}
+
+	return nil
+}
+
+// ValidateKind checks that every rule can be applied to a value of the specified type kind.
+// Bounds of integer kinds must be whole numbers within the range of the type with the bit size,
+// 0 meaning 64 bits, so that they can be written into the generated code.
+func (c *Constraints) ValidateKind(kind string, bitSize int) error {
+	for _, name := range c.names() {
+		if !slices.Contains(constraintKinds[name], kind) {
+			return fmt.Errorf("rule %q is not supported for %s values", name, kindName(kind))
+		}
+	}
+
+	if c != nil && (kind == KindInt || kind == KindUint) {
+		for _, bound := range []struct {
+			name  string
+			value *float64
+		}{{name: "min", value: c.Min}, {name: "max", value: c.Max}} {
+			if err := checkIntBound(bound.name, bound.value, kind, bitSize); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c != nil && c.Format != "" && !slices.Contains(formatKinds[c.Format], kind) {
+		return fmt.Errorf("format %q is not supported for %s values", c.Format, kindName(kind))
+	}
+
+	// Ports go up to 65535, so smaller integer types cannot hold every port
+	if c != nil && c.Format == FormatPort && (kind == KindInt || kind == KindUint) && bitSize != 0 && bitSize < portBitSize {
+		return fmt.Errorf("format %q is not supported for %d-bit %s values", c.Format, bitSize, kind)
+	}
+
+	return nil
+}
+
+// checkIntBound checks that the bound is a whole number within the range of the integer kind
+// with the bit size. A nil bound is valid.
+func checkIntBound(name string, bound *float64, kind string, bitSize int) error {
+	if bound == nil {
+		return nil
+	}
+
+	if *bound != math.Trunc(*bound) {
+		return fmt.Errorf("%s %s must be a whole number for %s values", name, formatBound(*bound), kind)
+	}
+
+	if bitSize == 0 {
+		bitSize = 64
+	}
+
+	lower, upper := 0.0, math.Ldexp(1, bitSize)
+	if kind == KindInt {
+		lower, upper = -math.Ldexp(1, bitSize-1), math.Ldexp(1, bitSize-1)
+	}
+
+	if *bound < lower || *bound >= upper {
+		return fmt.Errorf("%s %s is out of range for %d-bit %s values", name, formatBound(*bound), bitSize, kind)
+	}
+
+	return nil
+}
+
+// formatBound formats a numeric bound without an exponent.
+func formatBound(bound float64) string {
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
+
+// Check checks that a raw value of the specified type kind satisfies every rule.
+// Lengths of list and map values are counted by splitting them with the separator.
+// The value must already be parseable as the field type.
+func (c *Constraints) Check(value, kind, separator string) error {
+	if c.IsEmpty() {
+		return nil
+	}
+
+	if c.Min != nil || c.Max != nil {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("expected a number")
+		}
+
+		if c.Min != nil && number < *c.Min {
+			return fmt.Errorf("must be at least %s", c.GetMin())
+		}
+
+		if c.Max != nil && number > *c.Max {
+			return fmt.Errorf("must be at most %s", c.GetMax())
+		}
+	}
+
+	length := len(value)
+	if kind == KindSlice || kind == KindMap {
+		length = len(strings.Split(value, separator))
+	}
+
+	if c.MinLen != nil && length < *c.MinLen {
+		return fmt.Errorf("must have length at least %d", *c.MinLen)
+	}
+
+	if c.MaxLen != nil && length > *c.MaxLen {
+		return fmt.Errorf("must have length at most %d", *c.MaxLen)
+	}
+
+	if c.Pattern != "" && !regexp.MustCompile(c.Pattern).MatchString(value) {
+		return fmt.Errorf("must match pattern %s", c.Pattern)
+	}
+
+	if len(c.Values) > 0 && !slices.Contains(c.Values, value) {
+		return fmt.Errorf("must be one of %s", strings.Join(c.Values, ", "))
+	}
+
+	return checkFormat(c.Format, value)
+}
+
+// checkFormat checks that the value has the specified format.
+func checkFormat(format, value string) error {
+	switch format {
+	case FormatURL:
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			return errors.New("must be a valid URL")
+		}
+	case FormatEmail:
+		if _, err := mail.ParseAddress(value); err != nil {
+			return errors.New("must be a valid email address")
+		}
+	case FormatHostname:
+		if !hostnamePattern.MatchString(value) {
+			return errors.New("must be a valid hostname")
+		}
+	case FormatPort:
+		if port, err := strconv.Atoi(value); err != nil || port < 0 || port > 65535 {
+			return errors.New("must be a valid port")
+		}
+	case FormatDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return errors.New("must be a valid duration")
+		}
+	}
+
+	return nil
+}
+
+// names returns the YAML names of the defined rules except format.
+func (c *Constraints) names() []string {
+	if c == nil {
+		return nil
+	}
+
+	var names []string
+
+	if c.Min != nil {
+		names = append(names, "min")
+	}
+
+	if c.Max != nil {
+		names = append(names, "max")
+	}
+
+	if c.MinLen != nil {
+		names = append(names, "min_len")
+	}
+
+	if c.MaxLen != nil {
+		names = append(names, "max_len")
+	}
+
+	if c.Pattern != "" {
+		names = append(names, "pattern")
+	}
+
+	if len(c.Values) > 0 {
+		names = append(names, "values")
+	}
+
+	return names
+}
+
+// kindName returns a name of the type kind suitable for error messages.
+func kindName(kind string) string {
+	if kind == KindOther {
+		return "custom type"
+	}
+
+	return kind
+}
@@ -0,0 +1,200 @@
+package user_config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestConstraintsValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		constraints *user_config.Constraints
+		wantErr     string
+	}{
+		{name: "nil constraints", constraints: nil},
+		{
+			name:        "valid range",
+			constraints: &user_config.Constraints{Min: ptr(1.0), Max: ptr(65535.0)},
+		},
+		{
+			name:        "min greater than max",
+			constraints: &user_config.Constraints{Min: ptr(10.0), Max: ptr(1.0)},
+			wantErr:     "min 10 is greater than max 1",
+		},
+		{
+			name:        "negative length",
+			constraints: &user_config.Constraints{MinLen: ptr(-1)},
+			wantErr:     "must not be negative",
+		},
+		{
+			name:        "min_len greater than max_len",
+			constraints: &user_config.Constraints{MinLen: ptr(5), MaxLen: ptr(3)},
+			wantErr:     "min_len 5 is greater than max_len 3",
+		},
+		{
+			name:        "invalid pattern",
+			constraints: &user_config.Constraints{Pattern: "^[a-z"},
+			wantErr:     "invalid pattern",
+		},
+		{
+			name:        "unknown format",
+			constraints: &user_config.Constraints{Format: "ipv4"},
+			wantErr:     `unknown format "ipv4"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.constraints.Validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConstraintsValidateKind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		constraints *user_config.Constraints
+		kind        string
+		bitSize     int
+		wantErr     string
+	}{
+		{name: "nil constraints", constraints: nil, kind: user_config.KindInt},
+		{
+			name:        "fractional bound on int",
+			constraints: &user_config.Constraints{Min: ptr(1.5)},
+			kind:        user_config.KindInt,
+			wantErr:     "min 1.5 must be a whole number for int values",
+		},
+		{name: "fractional bound on float", constraints: &user_config.Constraints{Min: ptr(1.5)}, kind: user_config.KindFloat},
+		{
+			name:        "negative bound on uint",
+			constraints: &user_config.Constraints{Min: ptr(-1.0)},
+			kind:        user_config.KindUint,
+			wantErr:     "min -1 is out of range for 64-bit uint values",
+		},
+		{
+			name:        "bound overflows int8",
+			constraints: &user_config.Constraints{Max: ptr(1000.0)},
+			kind:        user_config.KindInt,
+			bitSize:     8,
+			wantErr:     "max 1000 is out of range for 8-bit int values",
+		},
+		{
+			name:        "bounds within int8",
+			constraints: &user_config.Constraints{Min: ptr(-128.0), Max: ptr(127.0)},
+			kind:        user_config.KindInt,
+			bitSize:     8,
+		},
+		{
+			name:        "bound overflows uint8",
+			constraints: &user_config.Constraints{Max: ptr(256.0)},
+			kind:        user_config.KindUint,
+			bitSize:     8,
+			wantErr:     "max 256 is out of range for 8-bit uint values",
+		},
+		{name: "range on int", constraints: &user_config.Constraints{Min: ptr(1.0)}, kind: user_config.KindInt},
+		{
+			name:        "range on string",
+			constraints: &user_config.Constraints{Max: ptr(1.0)},
+			kind:        user_config.KindString,
+			wantErr:     `rule "max" is not supported for string values`,
+		},
+		{name: "length on slice", constraints: &user_config.Constraints{MaxLen: ptr(3)}, kind: user_config.KindSlice},
+		{
+			name:        "pattern on custom type",
+			constraints: &user_config.Constraints{Pattern: "^a$"},
+			kind:        user_config.KindOther,
+			wantErr:     `rule "pattern" is not supported for custom type values`,
+		},
+		{name: "port on int", constraints: &user_config.Constraints{Format: "port"}, kind: user_config.KindInt},
+		{name: "port on int32", constraints: &user_config.Constraints{Format: "port"}, kind: user_config.KindInt, bitSize: 32},
+		{
+			name:        "port on uint16",
+			constraints: &user_config.Constraints{Format: "port"},
+			kind:        user_config.KindUint,
+			bitSize:     16,
+			wantErr:     `format "port" is not supported for 16-bit uint values`,
+		},
+		{
+			name:        "port on int8",
+			constraints: &user_config.Constraints{Format: "port"},
+			kind:        user_config.KindInt,
+			bitSize:     8,
+			wantErr:     `format "port" is not supported for 8-bit int values`,
+		},
+		{
+			name:        "url on url type",
+			constraints: &user_config.Constraints{Format: "url"},
+			kind:        user_config.KindURL,
+			wantErr:     `format "url" is not supported for url values`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.constraints.ValidateKind(tt.kind, tt.bitSize)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConstraintsRules(t *testing.T) {
+	t.Parallel()
+
+	constraints := &user_config.Constraints{
+		Min:     ptr(0.5),
+		Max:     ptr(1000000.0),
+		MinLen:  ptr(1),
+		MaxLen:  ptr(8),
+		Pattern: "^[a-z]+$",
+		Values:  []string{"a", "b"},
+		Format:  "hostname",
+	}
+
+	require.False(t, constraints.IsEmpty())
+	require.Equal(t, "0.5", constraints.GetMin())
+	require.Equal(t, "1000000", constraints.GetMax())
+	require.Equal(t, []string{
+		"min: 0.5",
+		"max: 1000000",
+		"min_len: 1",
+		"max_len: 8",
+		"pattern: ^[a-z]+$",
+		"values: a, b",
+		"format: hostname",
+	}, constraints.Rules())
+
+	var empty *user_config.Constraints
+
+	require.True(t, empty.IsEmpty())
+	require.Empty(t, empty.Rules())
+	require.Empty(t, empty.GetMin())
+}
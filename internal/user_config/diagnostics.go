@@ -0,0 +1,213 @@
+package user_config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position describes a location in a user_configuration file.
+type Position struct {
+	File   string // Path to the file
+	Line   int    // Line number, starting at 1
+	Column int    // Column number, starting at 1
+}
+
+// IsValid checks if the position points to a location in a file.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the position in the file:line:column format.
+// The column is omitted if it is unknown.
+func (p Position) String() string {
+	if !p.IsValid() {
+		return p.File
+	}
+
+	s := p.File + ":" + strconv.Itoa(p.Line)
+	if p.Column > 0 {
+		s += ":" + strconv.Itoa(p.Column)
+	}
+
+	return s
+}
+
+// Diagnostic describes a problem found at a specific location of a user_configuration file.
+type Diagnostic struct {
+	Pos     Position // Location of the problem, may be empty
+	Message string   // Description of the problem
+}
+
+// Error returns the diagnostic in the file:line:column: message format.
+func (d *Diagnostic) Error() string {
+	if pos := d.Pos.String(); pos != "" {
+		return pos + ": " + d.Message
+	}
+
+	return d.Message
+}
+
+// Diagnostics is a list of problems reported at once.
+type Diagnostics []*Diagnostic
+
+// Error returns all diagnostics, one per line.
+func (d Diagnostics) Error() string {
+	lines := make([]string, len(d))
+	for i, diag := range d {
+		lines[i] = diag.Error()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Err returns the diagnostics as an error.
+// Returns nil if the list is empty.
+func (d Diagnostics) Err() error {
+	if len(d) == 0 {
+		return nil
+	}
+
+	return d
+}
+
+// sort orders the diagnostics by file, line and column.
+// Diagnostics without a location keep their relative order and are placed first.
+func (d Diagnostics) sort() {
+	slices.SortStableFunc(d, func(a, b *Diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(a.Pos.File, b.Pos.File),
+			cmp.Compare(a.Pos.Line, b.Pos.Line),
+			cmp.Compare(a.Pos.Column, b.Pos.Column),
+		)
+	})
+}
+
+// diagnosticf creates a diagnostic at the specified position.
+func diagnosticf(pos Position, format string, args ...any) *Diagnostic {
+	return &Diagnostic{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// prefix returns a copy of the diagnostics with the prefix prepended to every message.
+func (d Diagnostics) prefix(format string, args ...any) Diagnostics {
+	prefix := fmt.Sprintf(format, args...)
+
+	result := make(Diagnostics, len(d))
+	for i, diag := range d {
+		result[i] = &Diagnostic{Pos: diag.Pos, Message: prefix + ": " + diag.Message}
+	}
+
+	return result
+}
+
+// AsDiagnostics returns the diagnostics contained in err.
+// Returns nil if err does not contain diagnostics.
+func AsDiagnostics(err error) Diagnostics {
+	var diags Diagnostics
+	if errors.As(err, &diags) {
+		return diags
+	}
+
+	var diag *Diagnostic
+	if errors.As(err, &diag) {
+		return Diagnostics{diag}
+	}
+
+	return nil
+}
+
+// positions stores locations of a YAML mapping and of the values of its keys.
+type positions struct {
+	node  Position            // Location of the mapping
+	keys  map[string]Position // Locations of the values by key
+	names map[string]Position // Locations of the keys
+	order []string            // Keys in the order of appearance
+}
+
+// newPositions collects locations of a YAML mapping node and of its values.
+func newPositions(node *yaml.Node) positions {
+	p := positions{
+		node: Position{Line: node.Line, Column: node.Column},
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return p
+	}
+
+	p.keys = make(map[string]Position, len(node.Content)/2)
+	p.names = make(map[string]Position, len(node.Content)/2)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		p.keys[key.Value] = Position{Line: value.Line, Column: value.Column}
+		p.names[key.Value] = Position{Line: key.Line, Column: key.Column}
+		p.order = append(p.order, key.Value)
+	}
+
+	return p
+}
+
+// at returns the location of the value of the key.
+// Returns the location of the mapping if the key is not present.
+func (p positions) at(key string) Position {
+	if pos, ok := p.keys[key]; ok {
+		return pos
+	}
+
+	return p.node
+}
+
+// setFile sets the file of all stored locations.
+func (p *positions) setFile(file string) {
+	p.node.File = file
+
+	for key, pos := range p.keys {
+		pos.File = file
+		p.keys[key] = pos
+	}
+
+	for key, pos := range p.names {
+		pos.File = file
+		p.names[key] = pos
+	}
+}
+
+// yamlErrorLine extracts the line number and the message from errors reported by the YAML decoder.
+var yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// parseDiagnostics converts an error reported by the decoder into diagnostics.
+// Diagnostics reported by the JSON and TOML parsers get the file set.
+func parseDiagnostics(file string, err error) Diagnostics {
+	var diag *Diagnostic
+	if errors.As(err, &diag) {
+		return Diagnostics{{Pos: Position{File: file, Line: diag.Pos.Line, Column: diag.Pos.Column}, Message: diag.Message}}
+	}
+
+	messages := []string{err.Error()}
+
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	diags := make(Diagnostics, 0, len(messages))
+
+	for _, message := range messages {
+		pos := Position{File: file}
+
+		if m := yamlErrorLine.FindStringSubmatch(message); m != nil {
+			pos.Line, _ = strconv.Atoi(m[1])
+			message = m[2]
+		}
+
+		diags = append(diags, &Diagnostic{Pos: pos, Message: message})
+	}
+
+	return diags
+}
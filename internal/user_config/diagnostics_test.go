@@ -0,0 +1,119 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestPositionString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pos  user_config.Position
+		want string
+	}{
+		{name: "full", pos: user_config.Position{File: "config.yaml", Line: 57, Column: 9}, want: "config.yaml:57:9"},
+		{name: "without column", pos: user_config.Position{File: "config.yaml", Line: 3}, want: "config.yaml:3"},
+		{name: "without line", pos: user_config.Position{File: "config.yaml"}, want: "config.yaml"},
+		{name: "empty", pos: user_config.Position{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.pos.String())
+		})
+	}
+}
+
+func TestConfigValidateDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		content   string
+		wantParse bool
+		want      []string
+	}{
+		{
+			name: "all problems with positions",
+			content: `types:
+  - name: LogLevel
+groups:
+  - name: App
+    fields:
+      - name: port
+        type: int
+        default: eighty
+      - name: level
+        type: LogLvel
+      - type: string
+  - name: App
+    fields:
+      - name: host
+        type: string
+`,
+			want: []string{
+				`config.yaml:2:5: type definition is required for type "LogLevel"`,
+				`config.yaml:8:18: invalid default value "eighty" for field "port" in group "App": expected int`,
+				`config.yaml:10:15: unknown type "LogLvel" for field "level" in group "App", did you mean "LogLevel"?`,
+				`config.yaml:11:9: invalid field in group "App": field name is required`,
+				`config.yaml:12:11: duplicate group name "App"`,
+			},
+		},
+		{
+			name: "no groups",
+			content: `options:
+  go_package: config
+`,
+			want: []string{`config.yaml:1:1: at least one group is required`},
+		},
+		{
+			name: "type mismatch",
+			content: `groups:
+  - name: App
+    fields:
+      - name: debug
+        type: bool
+        required: maybe
+`,
+			wantParse: true,
+			want:      []string{"config.yaml:6: cannot unmarshal !!str `maybe` into bool"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, map[string]string{"config.yaml": tt.content})
+
+			cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+			if !tt.wantParse {
+				require.NoError(t, err)
+				err = cfg.Validate()
+			}
+
+			diags := user_config.AsDiagnostics(err)
+			require.NotNil(t, diags)
+
+			got := make([]string, len(diags))
+			for i, diag := range diags {
+				rel, relErr := filepath.Rel(tmpDir, diag.Pos.File)
+				require.NoError(t, relErr)
+
+				diag.Pos.File = rel
+				got[i] = diag.Error()
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,43 +1,181 @@
 package user_config
 
 import (
-	"errors"
-	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/safeblock-dev/envgen/internal/template_funcs"
 )
 
 // Field represents an environment variable field user_configuration.
 // Example:
 //
 //	fields:
-//	  - name: Port             # Required: Environment variable name
-//	    type: int              # Required: Field type (built-in or custom type)
-//	    description: Port      # Optional: Field description
-//	    default: "8080"        # Optional: Default value
-//	    required: true         # Optional: Whether the field is required
-//	    example: "8080"        # Optional: Example value for documentation
-//	    options:               # Optional: Additional options
-//	      import: "custom/pkg" # Optional: Import path for custom types
-//	      name_field: Port     # Optional: Override struct field name
+//	  - name: Port                     # Required: Environment variable name
+//	    type: int                      # Required: Field type (built-in or custom type)
+//	    group: HealthConfig            # Optional: Nested group used instead of type
+//	    description: Port              # Optional: Field description
+//	    default: "8080"                # Optional: Default value
+//	    required: true                 # Optional: Whether the field is required
+//	    required_if: MODE=server       # Optional: Condition under which the field is required
+//	    required_unless: SOCKET        # Optional: Condition under which the field is not required
+//	    conflicts_with: [SOCKET]       # Optional: Variables that must not be set together with the field
+//	    separator: ";"                 # Optional: Separator of list elements and map entries
+//	    key_value_separator: "="       # Optional: Separator of map keys and values
+//	    example: "8080"                # Optional: Example value for documentation
+//	    secret: false                  # Optional: Whether the value is sensitive
+//	    shared: false                  # Optional: Whether other fields may use the same variable
+//	    since: "1.2"                   # Optional: Version that introduced the variable
+//	    deprecated: true               # Optional: Whether the variable is deprecated
+//	    deprecated_message: Use PORT   # Optional: Deprecation explanation
+//	    removed_in: "2.0"              # Optional: Version that removes the variable
+//	    renamed_from: [OLD_PORT]       # Optional: Previous environment variable names
+//	    tags: [worker]                 # Optional: Tags for selecting fields
+//	    profiles:                      # Optional: Overrides in environment profiles
+//	      prod:
+//	        required: true             # Optional: Default value and required flag
+//	    validate:                      # Optional: Validation rules
+//	      min: 1                       # Optional: Minimum numeric value
+//	      max: 65535                   # Optional: Maximum numeric value
+//	    options:                       # Optional: Additional options
+//	      import: "custom/pkg"         # Optional: Import path for custom types
+//	      name_field: Port             # Optional: Override struct field name
 type Field struct {
-	Name        string            `yaml:"name"`        // Required: Environment variable name
-	Type        string            `yaml:"type"`        // Required: Field type (built-in or custom type)
-	Description string            `yaml:"description"` // Optional: Field description
-	Default     string            `yaml:"default"`     // Optional: Default value
-	Required    bool              `yaml:"required"`    // Optional: Whether the field is required
-	Example     string            `yaml:"example"`     // Optional: Example value for documentation
-	Options     map[string]string `yaml:"options"`     // Optional: Field-specific options (import, name_field, etc)
+	Name              string                   `yaml:"name"`                // Required: Environment variable name
+	Type              string                   `yaml:"type"`                // Required: Field type (built-in or custom type)
+	Group             string                   `yaml:"group"`               // Optional: Nested group used instead of type
+	Description       string                   `yaml:"description"`         // Optional: Field description
+	Default           string                   `yaml:"default"`             // Optional: Default value
+	Required          bool                     `yaml:"required"`            // Optional: Whether the field is required
+	RequiredIf        string                   `yaml:"required_if"`         // Optional: Condition under which the field is required
+	RequiredUnless    string                   `yaml:"required_unless"`     // Optional: Condition under which the field is not required
+	ConflictsWith     []string                 `yaml:"conflicts_with"`      // Optional: Variables that must not be set together with the field
+	Separator         string                   `yaml:"separator"`           // Optional: Separator of list elements and map entries, "," by default
+	KeyValueSeparator string                   `yaml:"key_value_separator"` // Optional: Separator of map keys and values, ":" by default
+	Example           string                   `yaml:"example"`             // Optional: Example value for documentation
+	Secret            bool                     `yaml:"secret"`              // Optional: Whether the value is sensitive (passwords, keys, DSNs)
+	Shared            bool                     `yaml:"shared"`              // Optional: Whether other shared fields may use the same variable
+	Since             string                   `yaml:"since"`               // Optional: Version that introduced the variable
+	Deprecated        bool                     `yaml:"deprecated"`          // Optional: Whether the variable is deprecated
+	DeprecatedMessage string                   `yaml:"deprecated_message"`  // Optional: Deprecation explanation, e.g. the replacement
+	RemovedIn         string                   `yaml:"removed_in"`          // Optional: Version that removes the variable
+	RenamedFrom       []string                 `yaml:"renamed_from"`        // Optional: Previous full environment variable names
+	Tags              []string                 `yaml:"tags"`                // Optional: Tags for selecting fields
+	Profiles          map[string]*FieldProfile `yaml:"profiles"`            // Optional: Default values and required flags by profile
+	Constraints       *Constraints             `yaml:"validate"`            // Optional: Validation rules
+	Options           Options                  `yaml:"options"`             // Optional: Field-specific options (import, name_field, etc)
+
+	pos      positions         `yaml:"-"` // Locations of the field and its values (not serialized)
+	expanded map[string]string `yaml:"-"` // Values with resolved references to other variables (not serialized)
+}
+
+// EnvName returns the environment variable name of the field in a group with the prefix.
+func (f *Field) EnvName(prefix string) string {
+	return prefix + strings.ToUpper(template_funcs.ToSnakeCase(f.Name))
+}
+
+// DeprecationNote returns a human-readable deprecation note, e.g.
+// "Deprecated, will be removed in 2.0: use HTTP_PORT instead".
+// Returns an empty string if the field is not deprecated.
+func (f *Field) DeprecationNote() string {
+	if !f.Deprecated {
+		return ""
+	}
+
+	note := "Deprecated"
+	if f.RemovedIn != "" {
+		note += ", will be removed in " + f.RemovedIn
+	}
+
+	if f.DeprecatedMessage != "" {
+		note += ": " + f.DeprecatedMessage
+	}
+
+	return note
+}
+
+// UnmarshalYAML decodes the field and records its location in the file.
+// List and map default and example values may be written as YAML sequences and mappings.
+func (f *Field) UnmarshalYAML(node *yaml.Node) error {
+	type plain Field
+
+	decode := func(node *yaml.Node) error {
+		return node.Decode((*plain)(f))
+	}
+
+	if err := f.decodeCollections(node, decode); err != nil {
+		return err
+	}
+
+	f.pos = newPositions(node)
+
+	return nil
+}
+
+// GetPosition returns the location of the field in the file that declares it.
+func (f *Field) GetPosition() Position {
+	return f.pos.node
+}
+
+// HasConstraints checks if the field has at least one validation rule.
+func (f *Field) HasConstraints() bool {
+	return !f.Constraints.IsEmpty()
 }
 
 // Validate validates the field user_configuration.
 // Returns an error if required fields are missing.
 func (f *Field) Validate() error {
+	return f.validate().Err()
+}
+
+// validate returns every problem of the field user_configuration.
+func (f *Field) validate() Diagnostics {
+	var diags Diagnostics
+
 	if f.Name == "" {
-		return errors.New("field name is required")
+		diags = append(diags, diagnosticf(f.pos.node, "field name is required"))
 	}
 
-	if f.Type == "" {
-		return fmt.Errorf("field type is required for field %q", f.Name)
+	switch {
+	case f.Type == "" && f.Group == "":
+		diags = append(diags, diagnosticf(f.pos.node, "field type is required for field %q", f.Name))
+	case f.Type != "" && f.Group != "":
+		diags = append(diags, diagnosticf(f.pos.at("group"), "field %q cannot have both type and group", f.Name))
+	case f.Group != "" && (f.Default != "" || f.Example != "" || len(f.Profiles) > 0 || !f.Constraints.IsEmpty() || f.Secret):
+		diags = append(diags, diagnosticf(f.pos.at("group"),
+			"group field %q cannot have default, example, profiles, validation rules or secret", f.Name))
+	case f.Group != "" && (f.Separator != "" || f.KeyValueSeparator != ""):
+		diags = append(diags, diagnosticf(f.pos.at("group"),
+			"group field %q cannot have separator or key_value_separator", f.Name))
+	case f.Group != "" && f.HasConditions():
+		diags = append(diags, diagnosticf(f.pos.at("group"),
+			"group field %q cannot have required_if, required_unless or conflicts_with", f.Name))
 	}
 
-	return nil
+	if !f.Deprecated {
+		if f.DeprecatedMessage != "" {
+			diags = append(diags, diagnosticf(f.pos.at("deprecated_message"),
+				"deprecated_message requires deprecated: true for field %q", f.Name))
+		}
+
+		if f.RemovedIn != "" {
+			diags = append(diags, diagnosticf(f.pos.at("removed_in"),
+				"removed_in requires deprecated: true for field %q", f.Name))
+		}
+	}
+
+	for _, name := range f.RenamedFrom {
+		if strings.TrimSpace(name) == "" {
+			diags = append(diags, diagnosticf(f.pos.at("renamed_from"),
+				"empty previous name in renamed_from for field %q", f.Name))
+		}
+	}
+
+	if err := f.Constraints.Validate(); err != nil {
+		diags = append(diags, diagnosticf(f.pos.at("validate"),
+			"invalid validation rules for field %q: %s", f.Name, err))
+	}
+
+	return diags
 }
@@ -38,7 +38,7 @@ func TestFieldValidate(t *testing.T) {
 			field: user_config.Field{
 				Name: "port",
 				Type: "int",
-				Options: map[string]string{
+				Options: user_config.Options{
 					"default": "8080",
 				},
 			},
@@ -74,6 +74,45 @@ func TestFieldValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid deprecated field",
+			field: user_config.Field{
+				Name:              "port",
+				Type:              "int",
+				Deprecated:        true,
+				DeprecatedMessage: "use HTTP_PORT instead",
+				RemovedIn:         "2.0",
+				RenamedFrom:       []string{"SERVER_PORT"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "deprecated message without deprecated",
+			field: user_config.Field{
+				Name:              "port",
+				Type:              "int",
+				DeprecatedMessage: "use HTTP_PORT instead",
+			},
+			wantErr: true,
+		},
+		{
+			name: "removed in without deprecated",
+			field: user_config.Field{
+				Name:      "port",
+				Type:      "int",
+				RemovedIn: "2.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty previous name",
+			field: user_config.Field{
+				Name:        "port",
+				Type:        "int",
+				RenamedFrom: []string{""},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -92,6 +131,53 @@ func TestFieldValidate(t *testing.T) {
 	}
 }
 
+func TestFieldDeprecationNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		field user_config.Field
+		want  string
+	}{
+		{
+			name:  "not deprecated",
+			field: user_config.Field{Name: "port", DeprecatedMessage: "ignored"},
+			want:  "",
+		},
+		{
+			name:  "deprecated",
+			field: user_config.Field{Name: "port", Deprecated: true},
+			want:  "Deprecated",
+		},
+		{
+			name: "with message and version",
+			field: user_config.Field{
+				Name:              "port",
+				Deprecated:        true,
+				DeprecatedMessage: "use HTTP_PORT instead",
+				RemovedIn:         "2.0",
+			},
+			want: "Deprecated, will be removed in 2.0: use HTTP_PORT instead",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.field.DeprecationNote())
+		})
+	}
+}
+
+func TestFieldEnvName(t *testing.T) {
+	t.Parallel()
+
+	field := user_config.Field{Name: "maxConnections", Type: "int"}
+	require.Equal(t, "MAX_CONNECTIONS", field.EnvName(""))
+	require.Equal(t, "DB_MAX_CONNECTIONS", field.EnvName("DB_"))
+}
+
 func TestFieldOptions(t *testing.T) {
 	t.Parallel()
 
@@ -107,7 +193,7 @@ func TestFieldOptions(t *testing.T) {
 			field: user_config.Field{
 				Name: "port",
 				Type: "int",
-				Options: map[string]string{
+				Options: user_config.Options{
 					"import": "custom/pkg",
 				},
 			},
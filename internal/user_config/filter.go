@@ -0,0 +1,243 @@
+package user_config
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// CheckPatterns checks that the name patterns are valid glob patterns,
+// e.g. Database, *Config or Redis?.
+func CheckPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckFieldPatterns checks that the field patterns are valid glob patterns
+// in the Group.Field format, e.g. Database.Password or *.Debug.
+func CheckFieldPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, ".") {
+			return fmt.Errorf("invalid field pattern %q: expected Group.Field", pattern)
+		}
+	}
+
+	return CheckPatterns(patterns)
+}
+
+// matchAny reports whether the name matches any of the glob patterns.
+// Invalid patterns match nothing.
+func matchAny(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		ok, _ := path.Match(pattern, name)
+
+		return ok
+	})
+}
+
+// SelectGroups keeps only the groups matching the patterns and the groups they nest.
+// If onlyGroups is empty, no filtering is performed.
+func (c *Config) SelectGroups(onlyGroups []string) {
+	if len(onlyGroups) == 0 {
+		return
+	}
+
+	before := c.newExpander()
+	selected := make(map[string]bool)
+
+	var selectGroup func(name string)
+	selectGroup = func(name string) {
+		group := c.FindGroup(name)
+		if group == nil || selected[name] {
+			return
+		}
+
+		selected[name] = true
+
+		for _, f := range group.Fields {
+			if f.Group != "" {
+				selectGroup(f.Group)
+			}
+		}
+	}
+
+	for _, g := range c.Groups {
+		if matchAny(onlyGroups, g.Name) {
+			selectGroup(g.Name)
+		}
+	}
+
+	c.Groups = slices.DeleteFunc(c.Groups, func(g Group) bool { return !selected[g.Name] })
+	c.expander = nil
+	c.warnFilteredReferences(before)
+}
+
+// FilterFields removes the fields matching the Group.Field patterns, e.g. Database.Password or *.Debug.
+// Groups left without fields are removed together with the fields nesting them.
+// If ignoreFields is empty, no filtering is performed.
+func (c *Config) FilterFields(ignoreFields []string) {
+	if len(ignoreFields) == 0 {
+		return
+	}
+
+	counts, before := c.fieldCounts(), c.newExpander()
+
+	for i := range c.Groups {
+		group := &c.Groups[i]
+		group.Fields = slices.DeleteFunc(group.Fields, func(f Field) bool {
+			return matchAny(ignoreFields, group.Name+"."+f.Name)
+		})
+	}
+
+	c.prune(counts)
+	c.warnFilteredReferences(before)
+}
+
+// FilterTags keeps the fields whose tags match any of the included tag patterns and removes
+// the fields whose tags match any of the excluded tag patterns. The tags of a field include
+// the tags of its group. Nested groups are included with the tags of the groups and fields
+// nesting them, but excluded by their own tags only, since they are shared.
+// Groups left without fields are removed together with the fields nesting them.
+// If both lists are empty, no filtering is performed.
+func (c *Config) FilterTags(includeTags, excludeTags []string) {
+	if len(includeTags) == 0 && len(excludeTags) == 0 {
+		return
+	}
+
+	counts, before := c.fieldCounts(), c.newExpander()
+	inherited := make(map[string][]string, len(c.Groups))
+
+	for _, g := range c.Groups {
+		inherited[g.Name] = c.inheritedTags(g.Name, nil)
+	}
+
+	for i := range c.Groups {
+		group := &c.Groups[i]
+		group.Fields = slices.DeleteFunc(group.Fields, func(f Field) bool {
+			if slices.ContainsFunc(slices.Concat(group.Tags, f.Tags), func(tag string) bool { return matchAny(excludeTags, tag) }) {
+				return true
+			}
+
+			if len(includeTags) == 0 {
+				return false
+			}
+
+			return !slices.ContainsFunc(slices.Concat(inherited[group.Name], f.Tags), func(tag string) bool { return matchAny(includeTags, tag) })
+		})
+	}
+
+	c.prune(counts)
+	c.warnFilteredReferences(before)
+}
+
+// inheritedTags returns the tags of the group together with the tags of the groups
+// and fields nesting it. Groups that are already visited are skipped to stop reference cycles.
+func (c *Config) inheritedTags(name string, visited []string) []string {
+	group := c.FindGroup(name)
+	if group == nil || slices.Contains(visited, name) {
+		return nil
+	}
+
+	visited = append(visited, name)
+	tags := slices.Clone(group.Tags)
+
+	for _, g := range c.Groups {
+		for _, f := range g.Fields {
+			if f.Group == name {
+				tags = append(tags, f.Tags...)
+				tags = append(tags, c.inheritedTags(g.Name, visited)...)
+			}
+		}
+	}
+
+	return tags
+}
+
+// fieldCounts returns the number of fields of every group.
+func (c *Config) fieldCounts() map[string]int {
+	counts := make(map[string]int, len(c.Groups))
+	for _, g := range c.Groups {
+		counts[g.Name] = len(g.Fields)
+	}
+
+	return counts
+}
+
+// prune removes the fields nesting removed groups and the groups that lost all their fields
+// since the counts were taken, until no more groups are removed.
+func (c *Config) prune(counts map[string]int) {
+	c.expander = nil
+
+	for {
+		removed := false
+
+		for i := range c.Groups {
+			group := &c.Groups[i]
+			group.Fields = slices.DeleteFunc(group.Fields, func(f Field) bool {
+				return f.Group != "" && c.FindGroup(f.Group) == nil
+			})
+		}
+
+		c.Groups = slices.DeleteFunc(c.Groups, func(g Group) bool {
+			if len(g.Fields) == 0 && counts[g.Name] > 0 {
+				removed = true
+
+				return true
+			}
+
+			return false
+		})
+
+		if !removed {
+			return
+		}
+	}
+}
+
+// warnFilteredReferences adds a warning for every reference in a default or example value
+// and every condition of the remaining fields that refers to a variable removed by the filter.
+// Such values keep the reference to be resolved from the environment at runtime,
+// and such conditions are left out of the generated code.
+func (c *Config) warnFilteredReferences(before *expander) {
+	after := c.cachedExpander()
+
+	removed := func(ref string) bool {
+		_, declared := before.refs[ref]
+		_, kept := after.refs[ref]
+
+		return declared && !kept
+	}
+
+	for _, group := range c.Groups {
+		for _, field := range group.Fields {
+			for _, v := range []struct{ name, value string }{
+				{name: valueDefault, value: field.Default},
+				{name: valueExample, value: field.Example},
+			} {
+				for _, m := range referencePattern.FindAllStringSubmatch(v.value, -1) {
+					if removed(m[1]) {
+						c.warnings = append(c.warnings, diagnosticf(field.pos.at(v.name),
+							"reference ${%s} in %s value of field %q in group %q refers to a filtered out variable",
+							m[1], v.name, field.Name, group.Name))
+					}
+				}
+			}
+
+			for _, rule := range field.rules() {
+				if condition, err := before.condition(rule.name, rule.expr); err == nil && removed(condition.Ref) {
+					c.warnings = append(c.warnings, diagnosticf(field.pos.at(rule.name),
+						"%s condition %q of field %q in group %q refers to a filtered out variable and is ignored",
+						rule.name, rule.expr, field.Name, group.Name))
+				}
+			}
+		}
+	}
+
+	c.warnings.sort()
+}
@@ -0,0 +1,69 @@
+package user_config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileFormat is the format of a user_configuration file.
+type FileFormat string
+
+// Supported user_configuration file formats.
+const (
+	FileFormatYAML FileFormat = "yaml" // YAML, the default format
+	FileFormatJSON FileFormat = "json" // JSON
+	FileFormatTOML FileFormat = "toml" // TOML
+)
+
+// ParseFileFormat returns the file format with the specified name.
+// Returns an error if the format is not supported.
+func ParseFileFormat(name string) (FileFormat, error) {
+	switch format := FileFormat(strings.ToLower(name)); format {
+	case FileFormatYAML, FileFormatJSON, FileFormatTOML:
+		return format, nil
+	case "yml":
+		return FileFormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported config format %q, expected one of yaml, json, toml", name)
+	}
+}
+
+// DetectFileFormat detects the format of a user_configuration file by its extension.
+// Files with unknown extensions are treated as YAML.
+func DetectFileFormat(path string) FileFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FileFormatJSON
+	case ".toml":
+		return FileFormatTOML
+	default:
+		return FileFormatYAML
+	}
+}
+
+// parseNode parses the content of a user_configuration file into a YAML node.
+// JSON and TOML documents are converted to YAML nodes with the locations of the original keys,
+// so all formats share decoding, validation and diagnostics.
+// Returns nil if the document is empty.
+func parseNode(data []byte, format FileFormat) (*yaml.Node, error) {
+	switch format {
+	case FileFormatJSON:
+		return parseJSON(data)
+	case FileFormatTOML:
+		return parseTOML(data)
+	default:
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, err
+		}
+
+		if len(root.Content) == 0 {
+			return nil, nil
+		}
+
+		return root.Content[0], nil
+	}
+}
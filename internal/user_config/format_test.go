@@ -0,0 +1,252 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestParseFileFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    user_config.FileFormat
+		wantErr bool
+	}{
+		{name: "yaml", want: user_config.FileFormatYAML},
+		{name: "yml", want: user_config.FileFormatYAML},
+		{name: "JSON", want: user_config.FileFormatJSON},
+		{name: "toml", want: user_config.FileFormatTOML},
+		{name: "ini", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := user_config.ParseFileFormat(tt.name)
+			if tt.wantErr {
+				require.ErrorContains(t, err, "unsupported config format")
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDetectFileFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want user_config.FileFormat
+	}{
+		{path: "config.yaml", want: user_config.FileFormatYAML},
+		{path: "config.yml", want: user_config.FileFormatYAML},
+		{path: "config.json", want: user_config.FileFormatJSON},
+		{path: "dir/config.TOML", want: user_config.FileFormatTOML},
+		{path: "config", want: user_config.FileFormatYAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, user_config.DetectFileFormat(tt.path))
+		})
+	}
+}
+
+func TestNewFormats(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"config.yaml": `options:
+  go_package: config
+types:
+  - name: LogLevel
+    type: string
+    values: [debug, info]
+groups:
+  - name: App
+    prefix: APP_
+    fields:
+      - name: port
+        type: int
+        default: 8080
+        required: true
+        validate:
+          min: 1
+      - name: level
+        type: LogLevel
+`,
+		"config.json": `{
+	"options": {"go_package": "config"},
+	"types": [
+		{"name": "LogLevel", "type": "string", "values": ["debug", "info"]}
+	],
+	"groups": [
+		{
+			"name": "App",
+			"prefix": "APP_",
+			"fields": [
+				{"name": "port", "type": "int", "default": 8080, "required": true, "validate": {"min": 1}},
+				{"name": "level", "type": "LogLevel"}
+			]
+		}
+	]
+}
+`,
+		"config.toml": `[options]
+go_package = "config"
+
+[[types]]
+name = "LogLevel"
+type = "string"
+values = ["debug", "info"]
+
+[[groups]]
+name = "App"
+prefix = "APP_"
+
+[[groups.fields]]
+name = "port"
+type = "int"
+default = 8080
+required = true
+validate = { min = 1 }
+
+[[groups.fields]]
+name = "level"
+type = "LogLevel"
+`,
+	}
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, files)
+
+	want, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, want.Validate())
+
+	for _, name := range []string{"config.json", "config.toml"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := user_config.New(filepath.Join(tmpDir, name))
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+			require.Equal(t, marshal(t, want), marshal(t, cfg))
+		})
+	}
+}
+
+func TestNewFormatDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		opts    user_config.LoadOptions
+		want    string
+	}{
+		{
+			name: "json syntax error",
+			file: "config.json",
+			content: `{
+  "groups": [
+    {"name": "App",}
+  ]
+}`,
+			want: `config.json:3:20: invalid character '}' looking for beginning of object key string`,
+		},
+		{
+			name: "json unknown key",
+			file: "config.json",
+			content: `{
+  "groups": [
+    {"name": "App", "fields": [{"name": "port", "type": "int", "requried": true}]}
+  ]
+}`,
+			want: `config.json:3:64: unknown key "requried" in field "port" in group "App", did you mean "required"?`,
+		},
+		{
+			name: "json invalid default",
+			file: "config.json",
+			content: `{
+  "groups": [
+    {"name": "App", "fields": [{"name": "port", "type": "int", "default": "eighty"}]}
+  ]
+}`,
+			want: `config.json:3:75: invalid default value "eighty" for field "port" in group "App": expected int`,
+		},
+		{
+			name: "toml syntax error",
+			file: "config.toml",
+			content: `[[groups]
+name = "App"
+`,
+			want: `config.toml:1:9: was expecting token [[, but got unclosed table array key instead`,
+		},
+		{
+			name: "toml unknown key",
+			file: "config.toml",
+			content: `[[groups]]
+name = "App"
+
+[[groups.fields]]
+name = "port"
+type = "int"
+deafult = "8080"
+`,
+			want: `config.toml:7:1: unknown key "deafult" in field "port" in group "App", did you mean "default"?`,
+		},
+		{
+			name:    "format override",
+			file:    "config.txt",
+			content: `{"groups": [{"name": "App", "fields": [{"name": "port"}]}]}`,
+			opts:    user_config.LoadOptions{Format: user_config.FileFormatJSON},
+			want:    `config.txt:1:40: invalid field in group "App": field type is required for field "port"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, map[string]string{tt.file: tt.content})
+
+			cfg, err := user_config.NewWithOptions(filepath.Join(tmpDir, tt.file), tt.opts)
+			if err == nil {
+				err = cfg.Validate()
+			}
+
+			diags := user_config.AsDiagnostics(err)
+			require.Len(t, diags, 1)
+
+			diag := *diags[0]
+			diag.Pos.File = filepath.Base(diag.Pos.File)
+			require.Equal(t, tt.want, diag.Error())
+		})
+	}
+}
+
+// marshal returns the YAML representation of the configuration.
+func marshal(t *testing.T, cfg *user_config.Config) string {
+	t.Helper()
+
+	data, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+
+	return string(data)
+}
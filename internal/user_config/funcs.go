@@ -1,6 +1,9 @@
 package user_config
 
-import "sort"
+import (
+	"sort"
+	"strings"
+)
 
 // Functions that the template uses
 
@@ -9,11 +12,13 @@ func (c *Config) GetPath() string {
 	return c.path
 }
 
-// FindType finds a type definition by name.
-// Returns nil if the type is not found.
+// FindType finds a type definition by name. Types declared with from: library take
+// their definition from the library. Returns nil if the type is not declared.
 func (c *Config) FindType(typeName string) *TypeDefinition {
 	for _, t := range c.Types {
 		if t.Name == typeName {
+			t = t.resolved()
+
 			return &t
 		}
 	}
@@ -21,27 +26,35 @@ func (c *Config) FindType(typeName string) *TypeDefinition {
 	return nil
 }
 
-// HasOption checks if the specified option exists in the user_configuration.
-// This is used to conditionally include sections in templates based on user_configuration options.
-func (c *Config) HasOption(option string) bool {
-	if c.Options == nil {
-		return false
+// FindGroup finds a group by name.
+// Returns nil if the group is not found.
+func (c *Config) FindGroup(name string) *Group {
+	for i := range c.Groups {
+		if c.Groups[i].Name == name {
+			return &c.Groups[i]
+		}
 	}
 
-	_, ok := c.Options[option]
+	return nil
+}
 
-	return ok
+// HasOption checks if the specified option exists in the user_configuration.
+// This is used to conditionally include sections in templates based on user_configuration options.
+func (c *Config) HasOption(option string) bool {
+	return c.Options.Has(option)
 }
 
-// HasGroupOption checks if any field in the groups has a non-empty value for the specified option.
-// This is used to conditionally include sections in templates based on field options.
+// HasGroupOption checks if any group or field has the specified option.
+// This is used to conditionally include sections in templates based on group and field options.
 func (c *Config) HasGroupOption(option string) bool {
 	for _, group := range c.Groups {
-		for _, field := range group.Fields {
-			if field.Options != nil {
-				_, ok := field.Options[option]
+		if group.Options.Has(option) {
+			return true
+		}
 
-				return ok
+		for _, field := range group.Fields {
+			if field.Options.Has(option) {
+				return true
 			}
 		}
 	}
@@ -49,25 +62,23 @@ func (c *Config) HasGroupOption(option string) bool {
 	return false
 }
 
-// GetOption returns the value of the specified option from the user_configuration.
+// GetOption returns the value of the specified option from the user_configuration as a string.
 // If the option is not found, returns an empty string.
 func (c *Config) GetOption(option string) string {
-	if c.Options == nil {
-		return ""
-	}
-
-	return c.Options[option]
+	return c.Options.Get(option)
 }
 
-// GetGroupOption returns the value of the specified option from the first field that has it.
-// If no field has the option, returns an empty string.
+// GetGroupOption returns the value of the specified option as a string from the first group or field
+// that has it, checking each group before its fields. If no group or field has the option, returns an empty string.
 func (c *Config) GetGroupOption(option string) string {
 	for _, group := range c.Groups {
+		if group.Options.Has(option) {
+			return group.Options.Get(option)
+		}
+
 		for _, field := range group.Fields {
-			if field.Options != nil {
-				if value, ok := field.Options[option]; ok {
-					return value
-				}
+			if field.Options.Has(option) {
+				return field.Options.Get(option)
 			}
 		}
 	}
@@ -75,17 +86,36 @@ func (c *Config) GetGroupOption(option string) string {
 	return ""
 }
 
-// GetImports returns a list of unique imports from type definitions that are used in fields.
-func (c *Config) GetImports() []string {
-	// Early return if no types defined
-	if len(c.Types) == 0 {
-		return nil
+// GroupOption returns the value of the option resolved for the group:
+// the option of the group overrides the option of the configuration.
+// The value keeps its type, so templates can test booleans and range over lists.
+// If neither has the option, returns nil.
+func (c *Config) GroupOption(group *Group, option string) any {
+	if value, ok := group.Options[option]; ok {
+		return value
 	}
 
+	return c.Options[option]
+}
+
+// FieldOption returns the value of the option resolved for the field of the group:
+// the option of the field overrides the option of the group, which overrides the option
+// of the configuration. The value keeps its type. If none has the option, returns nil.
+func (c *Config) FieldOption(group *Group, field *Field, option string) any {
+	if value, ok := field.Options[option]; ok {
+		return value
+	}
+
+	return c.GroupOption(group, option)
+}
+
+// GetImports returns a list of unique imports from type definitions that are used in fields,
+// including the library types and the element, key and value types of lists and maps.
+func (c *Config) GetImports() []string {
 	// Create a map of type names to their imports for O(1) lookup
-	typeImports := make(map[string]string, len(c.Types))
+	typeImports := make(map[string]string)
 
-	for _, t := range c.Types {
+	for _, t := range c.AllTypes() {
 		if t.Import != "" {
 			typeImports[t.Name] = t.Import
 		}
@@ -97,8 +127,11 @@ func (c *Config) GetImports() []string {
 	// Collect imports from used types
 	for _, group := range c.Groups {
 		for _, field := range group.Fields {
-			if imp, exists := typeImports[field.Type]; exists {
-				uniqueImports[imp] = struct{}{}
+			// Split composite types such as []Duration or map[string]URL into type names
+			for _, name := range strings.FieldsFunc(field.Type, isTypeSeparator) {
+				if imp, exists := typeImports[name]; exists {
+					uniqueImports[imp] = struct{}{}
+				}
 			}
 		}
 	}
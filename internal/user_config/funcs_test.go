@@ -51,13 +51,13 @@ func TestHasOption(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		options  map[string]string
+		options  user_config.Options
 		option   string
 		expected bool
 	}{
 		{
 			name: "existing option",
-			options: map[string]string{
+			options: user_config.Options{
 				"go_package": "config",
 			},
 			option:   "go_package",
@@ -65,7 +65,7 @@ func TestHasOption(t *testing.T) {
 		},
 		{
 			name: "empty option",
-			options: map[string]string{
+			options: user_config.Options{
 				"empty": "",
 			},
 			option:   "empty",
@@ -73,7 +73,7 @@ func TestHasOption(t *testing.T) {
 		},
 		{
 			name: "non-existent option",
-			options: map[string]string{
+			options: user_config.Options{
 				"other": "value",
 			},
 			option:   "non_existent",
@@ -109,12 +109,22 @@ func TestHasGroupOption(t *testing.T) {
 			{
 				Fields: []user_config.Field{
 					{
-						Options: map[string]string{
+						Options: user_config.Options{
+							"go_name": "Name",
+						},
+					},
+					{
+						Options: user_config.Options{
 							"import": "custom/pkg",
 						},
 					},
 				},
 			},
+			{
+				Options: user_config.Options{
+					"md_hide": "true",
+				},
+			},
 		},
 	}
 
@@ -124,6 +134,8 @@ func TestHasGroupOption(t *testing.T) {
 		expected bool
 	}{
 		{name: "existing option", option: "import", expected: true},
+		{name: "first field option", option: "go_name", expected: true},
+		{name: "group option", option: "md_hide", expected: true},
 		{name: "non-existent option", option: "non_existent", expected: false},
 	}
 
@@ -148,13 +160,13 @@ func TestGetOption(t *testing.T) {
 	tests := []struct {
 		name     string
 		option   string
-		options  map[string]string
+		options  user_config.Options
 		expected string
 	}{
 		{
 			name:   "existing option",
 			option: "go_package",
-			options: map[string]string{
+			options: user_config.Options{
 				"go_package": "config",
 			},
 			expected: "config",
@@ -162,7 +174,7 @@ func TestGetOption(t *testing.T) {
 		{
 			name:     "non-existent option",
 			option:   "non_existent",
-			options:  map[string]string{},
+			options:  user_config.Options{},
 			expected: "",
 		},
 		{
@@ -195,12 +207,22 @@ func TestGetGroupOption(t *testing.T) {
 			{
 				Fields: []user_config.Field{
 					{
-						Options: map[string]string{
+						Options: user_config.Options{
+							"go_name": "Name",
+						},
+					},
+					{
+						Options: user_config.Options{
 							"import": "custom/pkg",
 						},
 					},
 				},
 			},
+			{
+				Options: user_config.Options{
+					"md_hide": "true",
+				},
+			},
 		},
 	}
 
@@ -210,6 +232,8 @@ func TestGetGroupOption(t *testing.T) {
 		expected string
 	}{
 		{name: "existing option", option: "import", expected: "custom/pkg"},
+		{name: "first field option", option: "go_name", expected: "Name"},
+		{name: "group option", option: "md_hide", expected: "true"},
 		{name: "non-existent option", option: "non_existent", expected: ""},
 	}
 
@@ -229,6 +253,79 @@ func TestGetGroupOption(t *testing.T) {
 	}
 }
 
+func TestGroupOption(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Options: user_config.Options{
+			"go_skip_env_tag": true,
+			"md_hide":         true,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		options  user_config.Options
+		option   string
+		expected any
+	}{
+		{name: "config option", options: nil, option: "go_skip_env_tag", expected: true},
+		{name: "group option overrides config option", options: user_config.Options{"go_skip_env_tag": false}, option: "go_skip_env_tag", expected: false},
+		{name: "empty group option overrides config option", options: user_config.Options{"md_hide": ""}, option: "md_hide", expected: ""},
+		{name: "group option", options: user_config.Options{"go_name": "Server"}, option: "go_name", expected: "Server"},
+		{name: "non-existent option", options: user_config.Options{"go_name": "Server"}, option: "non_existent", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			group := &user_config.Group{Name: "Server", Options: tt.options}
+			require.Equal(t, tt.expected, cfg.GroupOption(group, tt.option))
+		})
+	}
+}
+
+func TestFieldOption(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Options: user_config.Options{
+			"go_skip_env_tag": true,
+		},
+	}
+	group := &user_config.Group{
+		Name: "Debug",
+		Options: user_config.Options{
+			"md_hide": true,
+			"go_name": "DebugConfig",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		options  user_config.Options
+		option   string
+		expected any
+	}{
+		{name: "config option", options: nil, option: "go_skip_env_tag", expected: true},
+		{name: "field option overrides config option", options: user_config.Options{"go_skip_env_tag": false}, option: "go_skip_env_tag", expected: false},
+		{name: "group option", options: nil, option: "md_hide", expected: true},
+		{name: "field option overrides group option", options: user_config.Options{"md_hide": "false"}, option: "md_hide", expected: "false"},
+		{name: "list option", options: user_config.Options{"go_tags": []any{`json:"trace"`, `yaml:"trace"`}}, option: "go_tags", expected: []any{`json:"trace"`, `yaml:"trace"`}},
+		{name: "non-existent option", options: nil, option: "non_existent", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			field := &user_config.Field{Name: "Trace", Options: tt.options}
+			require.Equal(t, tt.expected, cfg.FieldOption(group, field, tt.option))
+		})
+	}
+}
+
 func TestGetImports(t *testing.T) {
 	t.Parallel()
 
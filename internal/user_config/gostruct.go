@@ -0,0 +1,436 @@
+package user_config
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/safeblock-dev/envgen/internal/gostruct"
+	"github.com/safeblock-dev/envgen/internal/template_funcs"
+)
+
+// Struct tags of the github.com/caarlos0/env package.
+const (
+	tagEnv         = "env"
+	tagEnvDefault  = "envDefault"
+	tagEnvPrefix   = "envPrefix"
+	tagEnvSep      = "envSeparator"
+	tagEnvKeyValSp = "envKeyValSeparator"
+)
+
+// deprecationNotice matches Go deprecation notices, e.g.
+// "Deprecated: use Pool instead (will be removed in 2.0)".
+var deprecationNotice = regexp.MustCompile(`^Deprecated: (.*?)(?: ?\(will be removed in ([^)]+)\))?$`)
+
+// qualifiedType matches type expressions referring to a type of another package,
+// e.g. time.Duration, *url.URL or []net.IP.
+var qualifiedType = regexp.MustCompile(`^(\*|\[\]|map\[\w+\])?(\w+)\.(\w+)$`)
+
+// goStructs maps the struct types of a Go package to groups.
+type goStructs struct {
+	pkg      *gostruct.Package
+	config   map[string]bool   // Struct types that declare environment variables
+	prefixes map[string]string // Common prefixes of the variables declared by the struct types
+	nested   map[string]string // Prefixes of the struct types added by the envPrefix tags of the fields that nest them
+	cfg      *Config
+	errs     Diagnostics
+}
+
+// FromGoStructs builds a user_configuration from Go struct types with the tags of the
+// github.com/caarlos0/env package, so that the go-env template generates equivalent structs.
+// Every struct type declaring variables with env tags or nesting such struct types becomes a group.
+// Doc comments become descriptions, envDefault tags become default values, the required
+// option becomes the required flag and other env options are kept in the go_env_options option.
+// Field names that do not match the variable names are kept in the go_name option.
+// Fields without env tags are skipped with a warning.
+// Returns the problems found as Diagnostics.
+func FromGoStructs(pkg *gostruct.Package) (*Config, error) {
+	s := &goStructs{
+		pkg:      pkg,
+		config:   make(map[string]bool),
+		prefixes: make(map[string]string),
+		nested:   make(map[string]string),
+		cfg: &Config{
+			Version: CurrentVersion,
+			Options: Options{"go_package": pkg.Name},
+		},
+	}
+
+	s.findConfigStructs()
+
+	for _, st := range pkg.Structs {
+		if s.config[st.Name] {
+			s.prefixes[st.Name] = s.commonPrefix(st)
+		}
+	}
+
+	for _, st := range pkg.Structs {
+		if s.config[st.Name] {
+			s.nestPrefixes(st)
+		}
+	}
+
+	for _, st := range pkg.Structs {
+		if s.config[st.Name] {
+			s.cfg.Groups = append(s.cfg.Groups, s.group(st))
+		}
+	}
+
+	if len(s.cfg.Groups) == 0 && len(s.errs) == 0 {
+		return nil, fmt.Errorf("no struct types with env tags in package %s", pkg.Name)
+	}
+
+	if len(s.errs) > 0 {
+		s.errs.sort()
+
+		return nil, s.errs
+	}
+
+	return s.cfg, nil
+}
+
+// findConfigStructs marks the struct types that declare variables, directly or by nesting other struct types.
+func (s *goStructs) findConfigStructs() {
+	for changed := true; changed; {
+		changed = false
+
+		for _, st := range s.pkg.Structs {
+			if s.config[st.Name] {
+				continue
+			}
+
+			for _, f := range st.Fields {
+				_, hasEnv := f.Tag.Lookup(tagEnv)
+				_, hasPrefix := f.Tag.Lookup(tagEnvPrefix)
+
+				if hasEnv || hasPrefix || s.config[s.pkg.StructName(f)] {
+					s.config[st.Name] = true
+					changed = true
+
+					break
+				}
+			}
+		}
+	}
+}
+
+// isNested reports whether the field nests a struct type that declares variables.
+func (s *goStructs) isNested(f gostruct.Field) bool {
+	return s.config[s.pkg.StructName(f)]
+}
+
+// envName returns the variable name of the env tag, e.g. PORT for env:"PORT,required".
+func envName(f gostruct.Field) string {
+	name, _, _ := strings.Cut(f.Tag.Get(tagEnv), ",")
+
+	return name
+}
+
+// isImported reports whether the field declares a variable or nests a struct type.
+// Unexported fields are imported only if they have env tags, like the go-env template generates them.
+func isImported(f gostruct.Field) bool {
+	if f.Tag.Get(tagEnv) == "-" {
+		return false
+	}
+
+	return unicode.IsUpper([]rune(f.Name)[0]) || envName(f) != ""
+}
+
+// commonPrefix returns the prefix shared by the variables of the struct type.
+// The prefix that makes the variable names match the field names is preferred,
+// e.g. APP_ for Port `env:"APP_PORT"` and Host `env:"APP_HOST"`.
+// Returns an empty string if the fields nest struct types with envPrefix tags outside of the prefix.
+func (s *goStructs) commonPrefix(st gostruct.Struct) string {
+	var names, candidates, nestedPrefixes []string
+
+	for _, f := range st.Fields {
+		if !isImported(f) {
+			continue
+		}
+
+		if s.isNested(f) {
+			nestedPrefixes = append(nestedPrefixes, f.Tag.Get(tagEnvPrefix))
+
+			continue
+		}
+
+		name := envName(f)
+		if name == "" {
+			continue
+		}
+
+		names = append(names, name)
+
+		if candidate, ok := strings.CutSuffix(name, (&Field{Name: f.Name}).EnvName("")); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	prefix := ""
+	if len(candidates) > 0 {
+		prefix = candidates[0]
+	}
+
+	matching := len(candidates) == len(names) && !slices.ContainsFunc(candidates, func(c string) bool { return c != prefix })
+
+	switch {
+	case len(names) == 0:
+		// Structs that only nest other structs under the same prefix keep it
+		if len(nestedPrefixes) > 0 && !slices.Contains(nestedPrefixes, "") && len(slices.Compact(slices.Clone(nestedPrefixes))) == 1 {
+			return nestedPrefixes[0]
+		}
+
+		return ""
+	case !matching:
+		prefix = sharedPrefix(names)
+	}
+
+	for _, p := range nestedPrefixes {
+		if !strings.HasPrefix(p, prefix) {
+			return ""
+		}
+	}
+
+	return prefix
+}
+
+// sharedPrefix returns the longest prefix ending with an underscore that all names share
+// and that leaves a non-empty rest of every name.
+func sharedPrefix(names []string) string {
+	prefix := names[0]
+
+	for _, name := range names {
+		for !strings.HasPrefix(name, prefix) || name == prefix {
+			i := strings.LastIndex(strings.TrimSuffix(prefix, "_"), "_")
+			if i < 0 {
+				return ""
+			}
+
+			prefix = prefix[:i+1]
+		}
+	}
+
+	return prefix
+}
+
+// nestPrefixes records the prefixes that the envPrefix tags of the fields of the struct type
+// add to the nested struct types. The prefixes must agree for all fields nesting a struct type.
+func (s *goStructs) nestPrefixes(st gostruct.Struct) {
+	for _, f := range st.Fields {
+		if !isImported(f) || !s.isNested(f) {
+			continue
+		}
+
+		name := s.pkg.StructName(f)
+		added := strings.TrimPrefix(f.Tag.Get(tagEnvPrefix), s.prefixes[st.Name])
+
+		if prev, ok := s.nested[name]; ok && prev != added {
+			s.errorf(st, f, "struct %s is nested with different prefixes %q and %q", name, prev, added)
+
+			continue
+		}
+
+		s.nested[name] = added
+	}
+}
+
+// group returns the group of the struct type.
+func (s *goStructs) group(st gostruct.Struct) Group {
+	prefix := s.prefixes[st.Name]
+	group := Group{
+		Name:        st.Name,
+		Description: st.Doc,
+		Prefix:      s.nested[st.Name] + prefix,
+	}
+
+	for _, f := range st.Fields {
+		if !isImported(f) {
+			continue
+		}
+
+		if s.isNested(f) {
+			field := Field{Name: f.Name, Group: s.pkg.StructName(f), Description: cmp.Or(f.Doc, f.Comment)}
+			if f.Embedded {
+				field.Options = Options{"go_include": true}
+			}
+
+			group.Fields = append(group.Fields, field)
+
+			continue
+		}
+
+		name := envName(f)
+		if name == "" {
+			s.warnf(st, f, "field %s of struct %s has no env tag, skipped", f.Name, st.Name)
+
+			continue
+		}
+
+		if field, ok := s.field(st, f, group.Prefix, strings.TrimPrefix(name, prefix)); ok {
+			group.Fields = append(group.Fields, field)
+		}
+	}
+
+	return group
+}
+
+// field returns the field of the variable with the name without the prefix of the group.
+func (s *goStructs) field(st gostruct.Struct, f gostruct.Field, prefix, name string) (Field, bool) {
+	field := Field{
+		Name:              f.Name,
+		Type:              s.fieldType(st, f),
+		Description:       cmp.Or(f.Doc, f.Comment),
+		Default:           f.Tag.Get(tagEnvDefault),
+		Separator:         f.Tag.Get(tagEnvSep),
+		KeyValueSeparator: f.Tag.Get(tagEnvKeyValSp),
+		Options:           make(Options),
+	}
+
+	if field.EnvName("") != name {
+		field.Name = template_funcs.ToPascalCase(strings.ToLower(name))
+		if field.EnvName("") != name {
+			field.Name = name
+		}
+
+		if field.EnvName("") != name {
+			s.errorf(st, f, "cannot derive a field name for variable %s of field %s in struct %s", name, f.Name, st.Name)
+
+			return Field{}, false
+		}
+
+		field.Options["go_name"] = f.Name
+	}
+
+	// Deprecation notices are written above the fields, descriptions next to them
+	if m := deprecationNotice.FindStringSubmatch(f.Doc); m != nil {
+		field.Deprecated = true
+		field.Description = f.Comment
+		field.RemovedIn = m[2]
+
+		if m[1] != field.EnvName(prefix)+" is deprecated" {
+			field.DeprecatedMessage = m[1]
+		}
+	}
+
+	_, opts, _ := strings.Cut(f.Tag.Get(tagEnv), ",")
+
+	var envOptions []string
+
+	for opt := range strings.SplitSeq(opts, ",") {
+		switch {
+		case opt == "":
+		case opt == "required":
+			field.Required = true
+		case opt == "expand" && strings.Contains(field.Default, "${"):
+			// The go-env template adds the option to defaults with references
+		default:
+			envOptions = append(envOptions, opt)
+		}
+	}
+
+	if len(envOptions) > 0 {
+		field.Options["go_env_options"] = strings.Join(envOptions, ",")
+	}
+
+	if tags := otherTags(f.Tag); tags != "" {
+		field.Options["go_tags"] = tags
+	}
+
+	if len(field.Options) == 0 {
+		field.Options = nil
+	}
+
+	return field, true
+}
+
+// fieldType returns the type of the field. Types referring to other packages are declared
+// as types with imports named after the referenced type, e.g. Duration for time.Duration,
+// URL for *url.URL and IPList for []net.IP. Library types with the same Go type, e.g. Duration,
+// are declared with from: library.
+func (s *goStructs) fieldType(st gostruct.Struct, f gostruct.Field) string {
+	m := qualifiedType.FindStringSubmatch(f.Type)
+	if m == nil {
+		for pkg, path := range f.Imports {
+			s.warnf(st, f, "type %s of field %s in struct %s refers to package %s (%s) that the generated code does not import",
+				f.Type, f.Name, st.Name, pkg, path)
+		}
+
+		return f.Type
+	}
+
+	name := m[3]
+	if s.pkg.FindStruct(name) != nil {
+		name = template_funcs.ToPascalCase(m[2]) + name
+	}
+
+	switch {
+	case m[1] == "[]":
+		name += "List"
+	case strings.HasPrefix(m[1], "map["):
+		name += "Map"
+	}
+
+	for base, i := name, 2; ; i++ {
+		t := s.cfg.FindType(name)
+		if t == nil {
+			typ := TypeDefinition{Name: name, Type: f.Type, Import: f.Imports[m[2]]}
+			if lib := libraryType(name); lib != nil && lib.Type == f.Type && lib.Import == typ.Import {
+				typ = TypeDefinition{Name: name, From: LibrarySource}
+			}
+
+			s.cfg.Types = append(s.cfg.Types, typ)
+
+			return name
+		}
+
+		if t.Type == f.Type {
+			return name
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+}
+
+// otherTags returns the tags of the field that are not read by the environment package,
+// e.g. json:"port" yaml:"port".
+func otherTags(tag reflect.StructTag) string {
+	var tags []string
+
+	rest := strings.TrimSpace(string(tag))
+	for rest != "" {
+		key, value, ok := strings.Cut(rest, ":")
+		if !ok {
+			break
+		}
+
+		quoted, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			break
+		}
+
+		switch key {
+		case tagEnv, tagEnvDefault, tagEnvPrefix, tagEnvSep, tagEnvKeyValSp:
+		default:
+			tags = append(tags, key+":"+quoted)
+		}
+
+		rest = strings.TrimSpace(value[len(quoted):])
+	}
+
+	return strings.Join(tags, " ")
+}
+
+// errorf adds an error for the field.
+func (s *goStructs) errorf(st gostruct.Struct, f gostruct.Field, format string, args ...any) {
+	s.errs = append(s.errs, &Diagnostic{Pos: Position{File: st.File, Line: f.Line}, Message: fmt.Sprintf(format, args...)})
+}
+
+// warnf adds a warning for the field.
+func (s *goStructs) warnf(st gostruct.Struct, f gostruct.Field, format string, args ...any) {
+	s.cfg.warnings = append(s.cfg.warnings, &Diagnostic{Pos: Position{File: st.File, Line: f.Line}, Message: fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,199 @@
+package user_config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/gostruct"
+	"github.com/safeblock-dev/envgen/internal/user_config"
+	"github.com/safeblock-dev/envgen/pkg/envgen"
+)
+
+func TestFromGoStructs(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.go": `package config
+
+import "time"
+
+// Config is the configuration of the service
+type Config struct {
+	DB   Database ` + "`envPrefix:\"DB_\"`" + `
+	HTTP HTTP     ` + "`envPrefix:\"HTTP_\"`" + `
+	Debug bool   ` + "`env:\"DEBUG\"`" + `
+	cache int
+}
+
+// Database holds connection settings
+type Database struct {
+	// Database host
+	Host    string        ` + "`env:\"HOST,required\" envDefault:\"localhost\"`" + `
+	Timeout time.Duration ` + "`env:\"TIMEOUT\" envDefault:\"5s\"`" + `
+	URL     string        ` + "`env:\"DSN,unset,required\" json:\"dsn\"`" + `
+}
+
+type HTTP struct {
+	Hosts []string ` + "`env:\"HOSTS\" envSeparator:\";\"`" + `
+	// Deprecated: use Hosts instead (will be removed in 2.0)
+	Host string ` + "`env:\"HOST\"`" + ` // HTTP host
+	Name string
+}
+`})
+
+	pkg, err := gostruct.ParseDir(tmpDir)
+	require.NoError(t, err)
+
+	cfg, err := user_config.FromGoStructs(pkg)
+	require.NoError(t, err)
+
+	var warnings []string
+	for _, w := range cfg.Warnings() {
+		w.Pos.File = filepath.Base(w.Pos.File)
+		warnings = append(warnings, w.Error())
+	}
+
+	require.Equal(t, []string{"config.go:25: field Name of struct HTTP has no env tag, skipped"}, warnings)
+
+	data, err := cfg.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, `version: 2
+options:
+  go_package: config
+types:
+  - name: Duration
+    from: library
+groups:
+  - name: Config
+    description: The configuration of the service
+    fields:
+      - name: DB
+        group: Database
+      - name: HTTP
+        group: HTTP
+      - name: Debug
+        type: bool
+  - name: Database
+    description: Connection settings
+    prefix: DB_
+    fields:
+      - name: Host
+        type: string
+        description: Database host
+        default: localhost
+        required: true
+      - name: Timeout
+        type: Duration
+        default: 5s
+      - name: Dsn
+        type: string
+        required: true
+        options:
+          go_env_options: unset
+          go_name: URL
+          go_tags: json:"dsn"
+  - name: HTTP
+    prefix: HTTP_
+    fields:
+      - name: Hosts
+        type: "[]string"
+        separator: ;
+      - name: Host
+        type: string
+        description: HTTP host
+        deprecated: true
+        deprecated_message: use Hosts instead
+        removed_in: "2.0"
+`, string(data))
+}
+
+func TestFromGoStructs_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		content   string
+		wantError string
+	}{
+		{
+			name:      "no env tags",
+			content:   "package config\n\ntype Config struct{ Port int }\n",
+			wantError: "no struct types with env tags in package config",
+		},
+		{
+			name: "different nested prefixes",
+			content: "package config\n\ntype A struct{ H Health `envPrefix:\"A_\"`; Port int `env:\"PORT\"` }\n\n" +
+				"type B struct{ H Health `envPrefix:\"B_\"`; Port int `env:\"B_PORT\"` }\n\ntype Health struct{ Port int `env:\"PORT\"` }\n",
+			wantError: `config.go:5: struct Health is nested with different prefixes "A_" and ""`,
+		},
+		{
+			name:      "invalid variable name",
+			content:   "package config\n\ntype A struct{ Port int `env:\"port\"` }\n",
+			wantError: "config.go:3: cannot derive a field name for variable port of field Port in struct A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, map[string]string{"config.go": tt.content})
+
+			pkg, err := gostruct.ParseDir(tmpDir)
+			require.NoError(t, err)
+
+			_, err = user_config.FromGoStructs(pkg)
+			require.ErrorContains(t, err, tt.wantError)
+		})
+	}
+}
+
+// TestFromGoStructs_RoundTrip checks that the go-env template generates the imported structs.
+func TestFromGoStructs_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"basic", "deprecated", "nested", "prefix", "types", "collections"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			want, err := gostruct.ParseDir(filepath.Join("../../templates_tests/go-env", name))
+			require.NoError(t, err)
+
+			cfg, err := user_config.FromGoStructs(want)
+			require.NoError(t, err)
+
+			data, err := cfg.Marshal()
+			require.NoError(t, err)
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, map[string]string{"envgen.yaml": string(data)})
+			require.NoError(t, os.Mkdir(filepath.Join(tmpDir, name), 0o755))
+
+			err = envgen.Generate(t.Context(), envgen.Options{
+				ConfigPath:   filepath.Join(tmpDir, "envgen.yaml"),
+				OutputPath:   filepath.Join(tmpDir, name, "config.go"),
+				TemplatePath: "../../templates/go-env",
+			})
+			require.NoError(t, err)
+
+			got, err := gostruct.ParseDir(filepath.Join(tmpDir, name))
+			require.NoError(t, err)
+			require.Equal(t, want.Name, got.Name)
+			require.Len(t, got.Structs, len(want.Structs))
+
+			for i, s := range want.Structs {
+				require.Equal(t, s.Name, got.Structs[i].Name)
+				require.Equal(t, s.Doc, got.Structs[i].Doc)
+
+				for j, f := range s.Fields {
+					g := got.Structs[i].Fields[j]
+					require.Equal(t, []any{f.Name, f.Embedded, f.Type, f.Tag}, []any{g.Name, g.Embedded, g.Type, g.Tag})
+				}
+			}
+		})
+	}
+}
@@ -1,8 +1,9 @@
 package user_config
 
 import (
-	"errors"
-	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Group represents a group of environment variables.
@@ -12,45 +13,125 @@ import (
 //	  - name: App                # Required: Group name
 //	    description: App         # Optional: Group description
 //	    prefix: APP_             # Optional: Environment variable prefix
+//	    tags: [api]              # Optional: Tags for selecting groups
 //	    options:                 # Optional: Additional options
 //	      go_name: Appuser       # Optional: Override struct name (Go-specific)
 //	    fields:                  # Required: At least one field must be defined
 //	      - name: Port
 //	        type: int
 type Group struct {
-	Name        string            `yaml:"name"`        // Required: Group name
-	Description string            `yaml:"description"` // Optional: Group description
-	Prefix      string            `yaml:"prefix"`      // Optional: Environment variable prefix
-	Options     map[string]string `yaml:"options"`     // Optional: Group-specific options (go_name, etc)
-	Fields      []Field           `yaml:"fields"`      // Required: At least one field must be defined
+	Name        string   `yaml:"name"`        // Required: Group name
+	Description string   `yaml:"description"` // Optional: Group description
+	Prefix      string   `yaml:"prefix"`      // Optional: Environment variable prefix
+	Tags        []string `yaml:"tags"`        // Optional: Tags for selecting groups and their fields
+	Options     Options  `yaml:"options"`     // Optional: Group-specific options (go_name, etc)
+	Fields      []Field  `yaml:"fields"`      // Required: At least one field must be defined
+
+	source string    `yaml:"-"` // Path to the file that declares the group (not serialized)
+	pos    positions `yaml:"-"` // Locations of the group and its values (not serialized)
+}
+
+// UnmarshalYAML decodes the group and records its location in the file.
+func (g *Group) UnmarshalYAML(node *yaml.Node) error {
+	type plain Group
+	if err := node.Decode((*plain)(g)); err != nil {
+		return err
+	}
+
+	g.pos = newPositions(node)
+
+	return nil
+}
+
+// GetSource returns the path to the file that declares the group.
+func (g *Group) GetSource() string {
+	return g.source
+}
+
+// GetPosition returns the location of the group in the file that declares it.
+func (g *Group) GetPosition() Position {
+	return g.pos.node
+}
+
+// setFile sets the file of the locations of the group and its fields.
+func (g *Group) setFile(file string) {
+	g.source = file
+	g.pos.setFile(file)
+
+	for i := range g.Fields {
+		g.Fields[i].pos.setFile(file)
+
+		if g.Fields[i].Constraints != nil {
+			g.Fields[i].Constraints.pos.setFile(file)
+		}
+
+		for _, profile := range g.Fields[i].Profiles {
+			if profile != nil {
+				profile.pos.setFile(file)
+			}
+		}
+	}
+}
+
+// HasConstraints checks if any field of the group has validation rules.
+func (g *Group) HasConstraints() bool {
+	for _, field := range g.Fields {
+		if field.HasConstraints() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasSecrets checks if any field of the group is marked as secret.
+func (g *Group) HasSecrets() bool {
+	for _, field := range g.Fields {
+		if field.Secret {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Validate validates the group user_configuration.
 // Returns an error if required fields are missing or if any field is invalid.
 func (g *Group) Validate() error {
+	return g.validate().Err()
+}
+
+// validate returns every problem of the group user_configuration and its fields.
+func (g *Group) validate() Diagnostics {
+	var diags Diagnostics
+
 	if g.Name == "" {
-		return errors.New("group name is required")
+		diags = append(diags, diagnosticf(g.pos.node, "group name is required"))
 	}
 
 	if len(g.Fields) == 0 {
-		return fmt.Errorf("at least one field is required in group %q", g.Name)
+		diags = append(diags, diagnosticf(g.pos.at("fields"), "at least one field is required in group %q", g.Name))
 	}
 
 	// Check field names uniqueness
 	fieldNames := make(map[string]struct{}, len(g.Fields))
 	for _, field := range g.Fields {
-		if _, exists := fieldNames[field.Name]; exists {
-			return fmt.Errorf("duplicate field name %q in group %q", field.Name, g.Name)
+		if _, exists := fieldNames[field.Name]; exists && field.Name != "" {
+			diags = append(diags, diagnosticf(field.pos.at("name"),
+				"duplicate field name %q in group %q", field.Name, g.Name))
 		}
 
 		fieldNames[field.Name] = struct{}{}
 	}
 
-	for i, field := range g.Fields {
-		if err := field.Validate(); err != nil {
-			return fmt.Errorf("invalid field %d in group %q: %w", i, g.Name, err)
+	for _, field := range g.Fields {
+		diags = append(diags, field.validate().prefix("invalid field in group %q", g.Name)...)
+
+		if envName := field.EnvName(g.Prefix); slices.Contains(field.RenamedFrom, envName) {
+			diags = append(diags, diagnosticf(field.pos.at("renamed_from"),
+				"field %q in group %q cannot be renamed from its own name %q", field.Name, g.Name, envName))
 		}
 	}
 
-	return nil
+	return diags
 }
@@ -60,6 +60,21 @@ func TestGroupValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "renamed from own name",
+			group: user_config.Group{
+				Name:   "app",
+				Prefix: "APP_",
+				Fields: []user_config.Field{
+					{
+						Name:        "port",
+						Type:        "int",
+						RenamedFrom: []string{"APP_PORT"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -98,7 +113,7 @@ func TestGroupOptions(t *testing.T) {
 						Type: "int",
 					},
 				},
-				Options: map[string]string{
+				Options: user_config.Options{
 					"go_name": "AppConfig",
 				},
 			},
@@ -116,7 +131,7 @@ func TestGroupOptions(t *testing.T) {
 						Type: "int",
 					},
 				},
-				Options: map[string]string{
+				Options: user_config.Options{
 					"go_name": "AppConfig",
 				},
 			},
@@ -154,3 +169,44 @@ func TestGroupOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupHasSecrets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		group user_config.Group
+		want  bool
+	}{
+		{
+			name: "secret field",
+			group: user_config.Group{
+				Name: "db",
+				Fields: []user_config.Field{
+					{Name: "host", Type: "string"},
+					{Name: "password", Type: "string", Secret: true},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "no secret fields",
+			group: user_config.Group{
+				Name:   "app",
+				Fields: []user_config.Field{{Name: "port", Type: "int"}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.group.HasSecrets())
+
+			cfg := user_config.Config{Groups: []user_config.Group{tt.group}}
+			require.Equal(t, tt.want, cfg.HasSecrets())
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package user_config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// includeResolver loads included files and merges them into the including configuration.
+//
+// Merge rules:
+//   - included files are merged in the order they are listed, nested includes first;
+//   - types and groups from included files are placed before the local ones;
+//   - profiles of all files are combined in the order they are declared;
+//   - options of a later include override options of an earlier one,
+//     and local options override options of every included file;
+//   - a type or group name declared in two different files is an error;
+//   - a file reachable through several include paths is merged only once.
+type includeResolver struct {
+	stack   []string            // Files currently being resolved, used for cycle detection
+	visited map[string]struct{} // Files that were already merged
+	opts    LoadOptions         // Options used to load included files
+}
+
+// newIncludeResolver creates a resolver for the configuration stored at root.
+func newIncludeResolver(root string, opts LoadOptions) *includeResolver {
+	return &includeResolver{
+		stack:   []string{root},
+		visited: map[string]struct{}{root: {}},
+		opts:    LoadOptions{AllowUnknownKeys: opts.AllowUnknownKeys},
+	}
+}
+
+// resolve loads all files included by cfg and merges them into cfg.
+func (r *includeResolver) resolve(cfg *Config) error {
+	if len(cfg.Include) == 0 {
+		return nil
+	}
+
+	merged := &Config{
+		Include: cfg.Include,
+		path:    cfg.path,
+	}
+
+	for _, include := range cfg.Include {
+		includePath := include
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(cfg.path), includePath)
+		}
+
+		includePath = filepath.Clean(includePath)
+
+		if slices.Contains(r.stack, includePath) {
+			chain := append(slices.Clone(r.stack), includePath)
+
+			return fmt.Errorf("include cycle detected: %s", strings.Join(chain, " -> "))
+		}
+
+		if _, ok := r.visited[includePath]; ok {
+			continue
+		}
+
+		r.visited[includePath] = struct{}{}
+
+		included, err := parseFile(includePath, r.opts)
+		if err != nil {
+			return fmt.Errorf("failed to include %q from %s: %w", include, cfg.path, err)
+		}
+
+		r.stack = append(r.stack, includePath)
+		err = r.resolve(included)
+		r.stack = r.stack[:len(r.stack)-1]
+
+		if err != nil {
+			return err
+		}
+
+		if err := merged.merge(included); err != nil {
+			return err
+		}
+	}
+
+	if err := merged.merge(cfg); err != nil {
+		return err
+	}
+
+	cfg.Options = merged.Options
+	cfg.Profiles = merged.Profiles
+	cfg.Types = merged.Types
+	cfg.Groups = merged.Groups
+
+	return nil
+}
+
+// merge appends types, groups and new profiles of other to c and overrides options of c with options of other.
+// Returns an error if a type or group of other is already declared in another file.
+func (c *Config) merge(other *Config) error {
+	for _, t := range other.Types {
+		if existing := c.FindType(t.Name); existing != nil && existing.source != t.source {
+			return diagnosticf(t.pos.at("name"), "type %q is defined in both %s and %s",
+				t.Name, existing.pos.at("name"), t.source)
+		}
+
+		c.Types = append(c.Types, t)
+	}
+
+	for _, g := range other.Groups {
+		if existing := c.FindGroup(g.Name); existing != nil && existing.source != g.source {
+			return diagnosticf(g.pos.at("name"), "group %q is defined in both %s and %s",
+				g.Name, existing.pos.at("name"), g.source)
+		}
+
+		c.Groups = append(c.Groups, g)
+	}
+
+	for _, profile := range other.Profiles {
+		if !slices.Contains(c.Profiles, profile) {
+			c.Profiles = append(c.Profiles, profile)
+		}
+	}
+
+	if len(other.Options) > 0 && c.Options == nil {
+		c.Options = make(Options, len(other.Options))
+	}
+
+	for k, v := range other.Options {
+		c.Options[k] = v
+	}
+
+	return nil
+}
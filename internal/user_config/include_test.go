@@ -0,0 +1,176 @@
+package user_config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+// writeFiles creates files with the given content relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+func TestNewInclude(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merge", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		writeFiles(t, tmpDir, map[string]string{
+			"config.yaml": `
+include:
+  - shared/postgres.yaml
+  - shared/redis.yaml
+options:
+  go_package: service
+groups:
+  - name: App
+    fields:
+      - name: Port
+        type: int
+`,
+			"shared/postgres.yaml": `
+include:
+  - types.yaml
+options:
+  go_package: shared
+  md_title: Shared
+groups:
+  - name: PostgresConfig
+    prefix: POSTGRES_
+    fields:
+      - name: URL
+        type: string
+`,
+			"shared/redis.yaml": `
+include:
+  - types.yaml
+options:
+  md_title: Redis
+groups:
+  - name: RedisConfig
+    prefix: REDIS_
+    fields:
+      - name: Timeout
+        type: Duration
+`,
+			"shared/types.yaml": `
+types:
+  - name: Duration
+    type: time.Duration
+    import: time
+`,
+		})
+
+		cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+		require.NoError(t, err)
+
+		groups := cfg.GetGroups()
+		require.Len(t, groups, 3)
+		require.Equal(t, "PostgresConfig", groups[0].Name)
+		require.Equal(t, "RedisConfig", groups[1].Name)
+		require.Equal(t, "App", groups[2].Name)
+		require.Equal(t, filepath.Join(tmpDir, "shared", "postgres.yaml"), groups[0].GetSource())
+
+		types := cfg.GetTypes()
+		require.Len(t, types, 1)
+		require.Equal(t, filepath.Join(tmpDir, "shared", "types.yaml"), types[0].GetSource())
+
+		require.Equal(t, "service", cfg.GetOption("go_package"))
+		require.Equal(t, "Redis", cfg.GetOption("md_title"))
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("duplicate group", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		writeFiles(t, tmpDir, map[string]string{
+			"config.yaml": `
+include: [shared.yaml]
+groups:
+  - name: App
+    fields:
+      - name: Port
+        type: int
+`,
+			"shared.yaml": `
+groups:
+  - name: App
+    fields:
+      - name: Host
+        type: string
+`,
+		})
+
+		_, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+		require.ErrorContains(t, err, `group "App" is defined in both`)
+	})
+
+	t.Run("duplicate type", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		writeFiles(t, tmpDir, map[string]string{
+			"config.yaml": `
+include: [a.yaml, b.yaml]
+groups:
+  - name: App
+    fields:
+      - name: Port
+        type: int
+`,
+			"a.yaml": `
+types:
+  - name: Duration
+    type: time.Duration
+`,
+			"b.yaml": `
+types:
+  - name: Duration
+    type: int64
+`,
+		})
+
+		_, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+		require.ErrorContains(t, err, `type "Duration" is defined in both`)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		writeFiles(t, tmpDir, map[string]string{
+			"config.yaml": "include: [a.yaml]\n",
+			"a.yaml":      "include: [b.yaml]\n",
+			"b.yaml":      "include: [a.yaml]\n",
+		})
+
+		_, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+		require.ErrorContains(t, err, "include cycle detected")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		writeFiles(t, tmpDir, map[string]string{
+			"config.yaml": "include: [missing.yaml]\n",
+		})
+
+		_, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+		require.ErrorContains(t, err, `failed to include "missing.yaml"`)
+	})
+}
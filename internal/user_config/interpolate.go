@@ -0,0 +1,333 @@
+package user_config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// referencePattern matches references to other variables in default and example values,
+// e.g. ${POSTGRES_HOST} or ${Postgres.Host}. References to environment variables that are not
+// declared by the configuration, e.g. ${HOME}, are kept as is and resolved at runtime.
+var referencePattern = regexp.MustCompile(`\$\{([^{}]*)\}`)
+
+// errUnresolved is returned for values that reference values with problems of their own.
+// Such problems are reported for the referenced values only.
+var errUnresolved = errors.New("unresolved reference")
+
+// Expanded values are stored by the name of the value.
+const (
+	valueDefault = "default"
+	valueExample = "example"
+	valueEnv     = "env"
+)
+
+// HasReferences checks if the default or example value references other variables.
+func (f *Field) HasReferences() bool {
+	return referencePattern.MatchString(f.Default) || referencePattern.MatchString(f.Example)
+}
+
+// ExpandedDefault returns the default value with references to other variables replaced by their default values.
+// Returns the raw default value if the references cannot be resolved.
+func (f *Field) ExpandedDefault() string {
+	if value, ok := f.expanded[valueDefault]; ok {
+		return value
+	}
+
+	return f.Default
+}
+
+// ExpandedExample returns the example value with references to other variables replaced by their
+// example values, or default values for variables without examples.
+// Returns the raw example value if the references cannot be resolved.
+func (f *Field) ExpandedExample() string {
+	if value, ok := f.expanded[valueExample]; ok {
+		return value
+	}
+
+	return f.Example
+}
+
+// EnvDefault returns the default value with references written as ${ENV_NAME},
+// the syntax understood by environment variable expansion at runtime.
+// Returns the raw default value if the references cannot be resolved.
+func (f *Field) EnvDefault() string {
+	if value, ok := f.expanded[valueEnv]; ok {
+		return value
+	}
+
+	return f.Default
+}
+
+// expander resolves references in default and example values.
+type expander struct {
+	refs   map[string]*Field   // Referenced fields by environment variable name and by Group.Field
+	labels map[*Field]string   // Names of the fields used in messages, Group.Field
+	envs   map[*Field][]string // Environment variable names of the fields, one for every group nesting them
+	groups map[*Field]*Group   // Groups that declare the fields
+}
+
+// cachedExpander returns the expander of the configuration for the template functions,
+// built on first use. Methods that remove groups or fields reset it.
+func (c *Config) cachedExpander() *expander {
+	if c.expander == nil {
+		c.expander = c.newExpander()
+	}
+
+	return c.expander
+}
+
+// newExpander indexes the fields of the configuration that can be referenced.
+// Nested groups are referenced by the composed names of their variables.
+func (c *Config) newExpander() *expander {
+	x := &expander{
+		refs:   make(map[string]*Field),
+		labels: make(map[*Field]string),
+		envs:   make(map[*Field][]string),
+		groups: make(map[*Field]*Group),
+	}
+
+	for i := range c.Groups {
+		group := &c.Groups[i]
+
+		for j := range group.Fields {
+			field := &group.Fields[j]
+			if field.Group != "" {
+				continue
+			}
+
+			label := group.Name + "." + field.Name
+			x.labels[field] = label
+			x.groups[field] = group
+
+			if _, exists := x.refs[label]; !exists {
+				x.refs[label] = field
+			}
+		}
+
+		if c.IsNested(group) {
+			continue
+		}
+
+		for _, variable := range c.Variables(group) {
+			if _, exists := x.refs[variable.Name]; !exists {
+				x.refs[variable.Name] = variable.Field
+			}
+
+			if !slices.Contains(x.envs[variable.Field], variable.Name) {
+				x.envs[variable.Field] = append(x.envs[variable.Field], variable.Name)
+			}
+		}
+	}
+
+	return x
+}
+
+// expandValues resolves the references in the default and example values of every field
+// and stores the expanded values. Returns a diagnostic for every unknown or cyclic reference.
+// When a profile is applied, only the default values overridden by the profile are reported,
+// at the location of the override.
+func (c *Config) expandValues() Diagnostics {
+	var diags Diagnostics
+
+	x := c.newExpander()
+
+	for i := range c.Groups {
+		group := &c.Groups[i]
+
+		for j := range group.Fields {
+			field := &group.Fields[j]
+			field.expanded = nil
+
+			if field.Group != "" || !field.HasReferences() {
+				continue
+			}
+
+			field.expanded = make(map[string]string, 3)
+
+			values := []struct {
+				name  string
+				value string
+			}{
+				{name: valueDefault, value: field.Default},
+				{name: valueExample, value: field.Example},
+			}
+
+			for _, v := range values {
+				expanded, err := x.expand(v.value, v.name == valueExample, []*Field{field})
+				if err == nil {
+					field.expanded[v.name] = expanded
+
+					continue
+				}
+
+				if errors.Is(err, errUnresolved) {
+					continue
+				}
+
+				name, pos := v.name, field.pos.at(v.name)
+
+				if c.profile != "" {
+					// Values that the profile does not override are reported for the field itself
+					override := field.Profiles[c.profile]
+					if v.name != valueDefault || override == nil || override.Default == nil {
+						continue
+					}
+
+					name, pos = fmt.Sprintf("profile %q default", c.profile), override.pos.at("default")
+				}
+
+				diags = append(diags, diagnosticf(pos, "invalid %s value %q for field %q in group %q: %s",
+					name, v.value, field.Name, group.Name, err))
+			}
+
+			if env, err := x.env(field.Default); err == nil {
+				field.expanded[valueEnv] = env
+			}
+		}
+	}
+
+	return diags
+}
+
+// expand replaces the references in the value of the last field of the stack
+// with the values of the referenced fields. The first field of the stack is the expanded field,
+// problems of other fields are reported as errUnresolved. References to secret fields are kept as ${ENV_NAME}
+// so that expanded values never reveal sensitive values.
+func (x *expander) expand(value string, example bool, stack []*Field) (string, error) {
+	var err error
+
+	expanded := referencePattern.ReplaceAllStringFunc(value, func(match string) string {
+		if err != nil {
+			return match
+		}
+
+		ref := match[2 : len(match)-1]
+
+		target, ok := x.refs[ref]
+
+		switch {
+		case !ok && x.external(ref):
+			return match
+		case !ok && len(stack) > 1:
+			err = errUnresolved
+		case !ok:
+			err = x.unknown(ref)
+		case x.ambiguous(target, ref) && len(stack) > 1:
+			err = errUnresolved
+		case x.ambiguous(target, ref):
+			err = fmt.Errorf("ambiguous reference ${%s}, use one of %s", ref, strings.Join(x.envs[target], ", "))
+		case target.Secret:
+			return "${" + x.envName(target, ref) + "}"
+		case target == stack[0]:
+			err = x.cycle(append(slices.Clone(stack), target))
+		case slices.Contains(stack, target):
+			err = errUnresolved
+		default:
+			targetValue := target.Default
+			if example && target.Example != "" {
+				targetValue = target.Example
+			}
+
+			var resolved string
+
+			resolved, err = x.expand(targetValue, example, append(stack, target))
+
+			return resolved
+		}
+
+		return match
+	})
+
+	return expanded, err
+}
+
+// env replaces the references in the value with ${ENV_NAME} references.
+func (x *expander) env(value string) (string, error) {
+	var err error
+
+	result := referencePattern.ReplaceAllStringFunc(value, func(match string) string {
+		ref := match[2 : len(match)-1]
+
+		target, ok := x.refs[ref]
+		if !ok && x.external(ref) {
+			return match
+		}
+
+		if !ok || x.ambiguous(target, ref) {
+			err = errUnresolved
+
+			return match
+		}
+
+		return "${" + x.envName(target, ref) + "}"
+	})
+
+	return result, err
+}
+
+// envName returns the environment variable name of the referenced field.
+func (x *expander) envName(field *Field, ref string) string {
+	if names := x.envs[field]; len(names) > 0 && strings.Contains(ref, ".") {
+		return names[0]
+	}
+
+	return ref
+}
+
+// ambiguous reports whether the Group.Field reference points to a field of a group nested by
+// several groups, which declares a variable with a different name for each of them.
+func (x *expander) ambiguous(field *Field, ref string) bool {
+	return strings.Contains(ref, ".") && len(x.envs[field]) > 1
+}
+
+// external reports whether an unknown reference refers to an environment variable that is not
+// declared by the configuration, e.g. ${HOME}. References in the Group.Field format and references
+// similar to a known reference are likely typos and are not external.
+func (x *expander) external(ref string) bool {
+	return !strings.Contains(ref, ".") && closestMatch(ref, x.names()) == ""
+}
+
+// warnExternalReferences adds a warning for every reference in a default or example value
+// to an environment variable that is not declared by the configuration.
+func (c *Config) warnExternalReferences() {
+	x := c.newExpander()
+
+	for _, group := range c.Groups {
+		for _, field := range group.Fields {
+			for _, v := range []struct{ name, value string }{
+				{name: valueDefault, value: field.Default},
+				{name: valueExample, value: field.Example},
+			} {
+				for _, m := range referencePattern.FindAllStringSubmatch(v.value, -1) {
+					if _, ok := x.refs[m[1]]; !ok && x.external(m[1]) {
+						c.warnings = append(c.warnings, diagnosticf(field.pos.at(v.name),
+							"reference ${%s} in %s value of field %q in group %q is not declared by the configuration "+
+								"and is resolved from the environment at runtime", m[1], v.name, field.Name, group.Name))
+					}
+				}
+			}
+		}
+	}
+}
+
+// unknown returns the error for an unknown reference with a suggestion of a similar known reference.
+func (x *expander) unknown(ref string) error {
+	if suggestion := closestMatch(ref, x.names()); suggestion != "" {
+		return fmt.Errorf("unknown reference ${%s}, did you mean ${%s}?", ref, suggestion)
+	}
+
+	return fmt.Errorf("unknown reference ${%s}", ref)
+}
+
+// cycle returns the error for a reference cycle through the fields.
+func (x *expander) cycle(fields []*Field) error {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = x.labels[field]
+	}
+
+	return fmt.Errorf("reference cycle %s", strings.Join(names, " -> "))
+}
@@ -0,0 +1,144 @@
+package user_config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// jsonParser converts a JSON document into YAML nodes.
+type jsonParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// parseJSON parses a JSON document into a YAML node.
+// Returns nil if the document is empty.
+func parseJSON(data []byte) (*yaml.Node, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	p := &jsonParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+
+	// The token stream reports syntax errors less precisely than a full decode
+	if err := json.Unmarshal(data, new(any)); err != nil {
+		return nil, p.error(err)
+	}
+
+	return p.value()
+}
+
+// value converts the next JSON value into a YAML node.
+func (p *jsonParser) value() (*yaml.Node, error) {
+	pos := p.position(p.next())
+
+	token, err := p.dec.Token()
+	if err != nil {
+		return nil, p.error(err)
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Line: pos.Line, Column: pos.Column}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+
+			for p.dec.More() {
+				keyPos := p.position(p.next())
+
+				key, err := p.dec.Token()
+				if err != nil {
+					return nil, p.error(err)
+				}
+
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+
+				node.Content = append(node.Content, &yaml.Node{
+					Kind:   yaml.ScalarNode,
+					Tag:    "!!str",
+					Value:  key.(string),
+					Line:   keyPos.Line,
+					Column: keyPos.Column,
+				}, value)
+			}
+		} else {
+			node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+
+			for p.dec.More() {
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+
+				node.Content = append(node.Content, value)
+			}
+		}
+
+		// Consume the closing delimiter
+		if _, err := p.dec.Token(); err != nil {
+			return nil, p.error(err)
+		}
+	case string:
+		node.Tag, node.Value = "!!str", token
+	case json.Number:
+		node.Tag, node.Value = "!!int", token.String()
+		if strings.ContainsAny(node.Value, ".eE") {
+			node.Tag = "!!float"
+		}
+	case bool:
+		node.Tag, node.Value = "!!bool", strconv.FormatBool(token)
+	case nil:
+		node.Tag, node.Value = "!!null", "null"
+	}
+
+	return node, nil
+}
+
+// next returns the offset of the next token.
+func (p *jsonParser) next() int {
+	offset := int(p.dec.InputOffset())
+
+	for offset < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[offset]) >= 0 {
+		offset++
+	}
+
+	return offset
+}
+
+// position converts an offset in the document into a location.
+func (p *jsonParser) position(offset int) Position {
+	offset = min(offset, len(p.data))
+	lineStart := bytes.LastIndexByte(p.data[:offset], '\n') + 1
+
+	return Position{
+		Line:   bytes.Count(p.data[:offset], []byte{'\n'}) + 1,
+		Column: utf8.RuneCount(p.data[lineStart:offset]) + 1,
+	}
+}
+
+// error converts an error reported by the JSON decoder into a diagnostic.
+func (p *jsonParser) error(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// The offset points past the invalid character
+		return diagnosticf(p.position(max(int(syntaxErr.Offset)-1, 0)), "%s", syntaxErr.Error())
+	}
+
+	if errors.Is(err, io.EOF) {
+		return diagnosticf(p.position(len(p.data)), "unexpected end of JSON input")
+	}
+
+	return err
+}
@@ -0,0 +1,91 @@
+package user_config
+
+import "strings"
+
+// Kinds of Go types used to decide which checks apply to a field.
+const (
+	KindInt      = "int"      // Signed integer types
+	KindUint     = "uint"     // Unsigned integer types
+	KindFloat    = "float"    // Floating-point types
+	KindBool     = "bool"     // Boolean type
+	KindString   = "string"   // String type
+	KindDuration = "duration" // time.Duration
+	KindURL      = "url"      // url.URL and *url.URL
+	KindIP       = "ip"       // net.IP
+	KindByteSize = "bytesize" // datasize.ByteSize
+	KindSlice    = "slice"    // Slice types
+	KindMap      = "map"      // Map types
+	KindOther    = ""         // Any other type
+)
+
+// TypeKind returns the kind of the specified Go type.
+// Returns KindOther if the type is not a built-in type known to envgen.
+func TypeKind(goType string) string {
+	goType = strings.TrimSpace(goType)
+
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		return KindInt
+	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return KindUint
+	case "float32", "float64":
+		return KindFloat
+	case "bool":
+		return KindBool
+	case "string":
+		return KindString
+	case "time.Duration":
+		return KindDuration
+	case "url.URL", "*url.URL":
+		return KindURL
+	case "net.IP":
+		return KindIP
+	case "datasize.ByteSize":
+		return KindByteSize
+	}
+
+	switch {
+	case strings.HasPrefix(goType, "[]"):
+		return KindSlice
+	case strings.HasPrefix(goType, "map["):
+		return KindMap
+	default:
+		return KindOther
+	}
+}
+
+// ResolveType returns the Go type of the specified field type.
+// Custom types are resolved to their definition, other types are returned as is.
+func (c *Config) ResolveType(typeName string) string {
+	if t := c.FindType(typeName); t != nil {
+		return t.Type
+	}
+
+	return typeName
+}
+
+// GoType returns the Go type declared for the specified field type. Custom types are resolved
+// to their definition, enum types keep their name as they are generated with the code, and
+// the element, key and value types of lists and maps are resolved the same way.
+func (c *Config) GoType(typeName string) string {
+	typeName = strings.TrimSpace(typeName)
+
+	if t := c.FindType(typeName); t != nil {
+		if t.IsEnum() {
+			return t.Name
+		}
+
+		return t.Type
+	}
+
+	switch TypeKind(typeName) {
+	case KindSlice:
+		return "[]" + c.GoType(ElemType(typeName))
+	case KindMap:
+		key, value, _ := splitMapType(typeName)
+
+		return "map[" + c.GoType(key) + "]" + c.GoType(value)
+	default:
+		return typeName
+	}
+}
@@ -0,0 +1,82 @@
+package user_config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestTypeKind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		goType   string
+		expected string
+	}{
+		{goType: "int", expected: user_config.KindInt},
+		{goType: "int64", expected: user_config.KindInt},
+		{goType: "uint16", expected: user_config.KindUint},
+		{goType: "float64", expected: user_config.KindFloat},
+		{goType: "bool", expected: user_config.KindBool},
+		{goType: "string", expected: user_config.KindString},
+		{goType: "time.Duration", expected: user_config.KindDuration},
+		{goType: "*url.URL", expected: user_config.KindURL},
+		{goType: "[]string", expected: user_config.KindSlice},
+		{goType: "map[string]int", expected: user_config.KindMap},
+		{goType: "zerolog.Level", expected: user_config.KindOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goType, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, user_config.TypeKind(tt.goType))
+		})
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Types: []user_config.TypeDefinition{
+			{Name: "Duration", Type: "time.Duration"},
+		},
+	}
+
+	require.Equal(t, "time.Duration", cfg.ResolveType("Duration"))
+	require.Equal(t, "int", cfg.ResolveType("int"))
+}
+
+func TestGoType(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Types: []user_config.TypeDefinition{
+			{Name: "Timeout", Type: "time.Duration", Import: "time"},
+			{Name: "Level", Type: "string", Kind: user_config.EnumKind, Values: []user_config.TypeValue{{Value: "info"}}},
+		},
+	}
+
+	tests := []struct {
+		typeName string
+		want     string
+	}{
+		{typeName: "int", want: "int"},
+		{typeName: "Timeout", want: "time.Duration"},
+		{typeName: "Level", want: "Level"},
+		{typeName: "[]Timeout", want: "[]time.Duration"},
+		{typeName: "map[Level]Timeout", want: "map[Level]time.Duration"},
+		{typeName: "map[string][]Timeout", want: "map[string][]time.Duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, cfg.GoType(tt.typeName))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package user_config
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// LibrarySource is the source of types that take their definition from the library of predefined types.
+const LibrarySource = "library"
+
+// libraryTypes are predefined type definitions that types declared with from: library take
+// their definition from, e.g.
+//
+//	types:
+//	  - name: Duration
+//	    from: library
+var libraryTypes = []TypeDefinition{
+	{Name: "Duration", Type: "time.Duration", Import: "time", Description: "Duration, e.g. 30s or 5m", Example: "30s"},
+	{
+		Name:        "URL",
+		Type:        "url.URL",
+		Import:      "net/url",
+		Description: "URL, e.g. https://example.com/api",
+		Example:     "https://example.com/api",
+	},
+	{Name: "IP", Type: "net.IP", Import: "net", Description: "IPv4 or IPv6 address, e.g. 10.0.0.1 or ::1", Example: "10.0.0.1"},
+	{
+		Name:        "ByteSize",
+		Type:        "datasize.ByteSize",
+		Import:      "github.com/c2h5oh/datasize",
+		Description: "Size in bytes with an optional unit, e.g. 512KB or 10MB",
+		Example:     "10MB",
+	},
+	{
+		Name:        "LogLevel",
+		Type:        "string",
+		Kind:        EnumKind,
+		Description: "Logging level",
+		Example:     "info",
+		Values:      []TypeValue{{Value: "debug"}, {Value: "info"}, {Value: "warn"}, {Value: "error"}},
+	},
+}
+
+// LibraryTypes returns the predefined type definitions that types can take their definition from.
+func LibraryTypes() []TypeDefinition {
+	types := make([]TypeDefinition, len(libraryTypes))
+	for i, t := range libraryTypes {
+		types[i] = t
+		types[i].Values = slices.Clone(t.Values)
+		types[i].library = true
+	}
+
+	return types
+}
+
+// IsLibrary reports whether the type takes its definition from the library of predefined types.
+func (t *TypeDefinition) IsLibrary() bool {
+	return t.library
+}
+
+// libraryType returns the library type with the name or nil if there is none.
+func libraryType(name string) *TypeDefinition {
+	for _, t := range LibraryTypes() {
+		if t.Name == name {
+			return &t
+		}
+	}
+
+	return nil
+}
+
+// libraryTypeNames returns the names of the library types.
+func libraryTypeNames() []string {
+	names := make([]string, 0, len(libraryTypes))
+	for _, t := range libraryTypes {
+		names = append(names, t.Name)
+	}
+
+	return names
+}
+
+// resolved returns the type with the definition of the library type with the same name
+// if the type is declared with from: library. Keys declared next to from, e.g. description
+// or example, override the library definition.
+func (t TypeDefinition) resolved() TypeDefinition {
+	if t.From != LibrarySource {
+		return t
+	}
+
+	lib := libraryType(t.Name)
+	if lib == nil {
+		return t
+	}
+
+	t.Type = lib.Type
+	t.Kind = cmp.Or(t.Kind, lib.Kind)
+	t.Import = cmp.Or(t.Import, lib.Import)
+	t.Description = cmp.Or(t.Description, lib.Description)
+	t.Example = cmp.Or(t.Example, lib.Example)
+
+	if t.Values == nil {
+		t.Values = lib.Values
+	}
+
+	t.library = true
+
+	return t
+}
+
+// validateSource checks that a type declared with from takes its definition from a known library type.
+func (t *TypeDefinition) validateSource() *Diagnostic {
+	switch {
+	case t.From == "":
+		return nil
+	case t.From != LibrarySource:
+		return diagnosticf(t.pos.at("from"), "unknown source %q of type %q, expected %s", t.From, t.Name, LibrarySource)
+	case libraryType(t.Name) == nil:
+		return diagnosticf(t.pos.at("name"), "unknown library type %q, expected one of %s",
+			t.Name, strings.Join(libraryTypeNames(), ", "))
+	case t.Type != "":
+		return diagnosticf(t.pos.at("type"), "type %q cannot declare both from and type", t.Name)
+	default:
+		return nil
+	}
+}
+
+// AllTypes returns the types declared in the configuration, with the definitions of the types
+// declared with from: library taken from the library.
+func (c *Config) AllTypes() []TypeDefinition {
+	if c.Types == nil {
+		return nil
+	}
+
+	types := make([]TypeDefinition, len(c.Types))
+	for i, t := range c.Types {
+		types[i] = t.resolved()
+	}
+
+	return types
+}
+
+// usesType reports whether any field refers to the type, possibly inside a composite type
+// such as []LogLevel or map[string]LogLevel.
+func (c *Config) usesType(name string) bool {
+	for _, g := range c.Groups {
+		for _, f := range g.Fields {
+			if slices.Contains(strings.FieldsFunc(f.Type, isTypeSeparator), name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
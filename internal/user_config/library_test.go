@@ -0,0 +1,157 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestConfig_FindType_Library(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Types: []user_config.TypeDefinition{
+			{Name: "Duration", Type: "int64"},
+			{Name: "IP", From: user_config.LibrarySource},
+			{Name: "LogLevel", From: user_config.LibrarySource, Description: "Level of the logged messages"},
+		},
+		Groups: []user_config.Group{{
+			Name: "App",
+			Fields: []user_config.Field{
+				{Name: "Timeout", Type: "Duration"},
+				{Name: "Hosts", Type: "[]IP"},
+				{Name: "Level", Type: "LogLevel"},
+				{Name: "API", Type: "URL"},
+			},
+		}},
+	}
+
+	duration := cfg.FindType("Duration")
+	require.NotNil(t, duration)
+	require.Equal(t, "int64", duration.Type)
+	require.False(t, duration.IsLibrary())
+
+	ip := cfg.FindType("IP")
+	require.NotNil(t, ip)
+	require.Equal(t, "net.IP", ip.Type)
+	require.Equal(t, "net", ip.Import)
+	require.Equal(t, "10.0.0.1", ip.Example)
+	require.True(t, ip.IsLibrary())
+
+	level := cfg.FindType("LogLevel")
+	require.NotNil(t, level)
+	require.True(t, level.IsEnum())
+	require.Equal(t, "Level of the logged messages", level.Description)
+	require.Equal(t, []string{"debug", "info", "warn", "error"}, level.ValueNames())
+
+	require.Nil(t, cfg.FindType("URL"), "library types are used only when declared")
+	require.Nil(t, cfg.FindType("Unknown"))
+
+	names := make([]string, 0, len(cfg.AllTypes()))
+	for _, typ := range cfg.AllTypes() {
+		names = append(names, typ.Name)
+	}
+
+	require.Equal(t, []string{"Duration", "IP", "LogLevel"}, names)
+	require.True(t, cfg.HasEnums())
+
+	cfg.FilterTypes([]string{"Log*"})
+	require.Nil(t, cfg.FindType("LogLevel"))
+	require.False(t, cfg.HasEnums())
+}
+
+func TestConfig_GetImports_Library(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Types: []user_config.TypeDefinition{
+			{Name: "Duration", From: user_config.LibrarySource},
+			{Name: "URL", From: user_config.LibrarySource},
+			{Name: "LogLevel", From: user_config.LibrarySource},
+			{Name: "IP", From: user_config.LibrarySource},
+			{Name: "ByteSize", From: user_config.LibrarySource},
+		},
+		Groups: []user_config.Group{{
+			Name: "App",
+			Fields: []user_config.Field{
+				{Name: "Timeout", Type: "Duration"},
+				{Name: "API", Type: "URL"},
+				{Name: "Level", Type: "LogLevel"},
+				{Name: "Addresses", Type: "[]IP"},
+				{Name: "Limits", Type: "map[string]ByteSize"},
+			},
+		}},
+	}
+
+	require.Equal(t, []string{"github.com/c2h5oh/datasize", "net", "net/url", "time"}, cfg.GetImports())
+}
+
+func TestLibraryTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, typ := range user_config.LibraryTypes() {
+		require.NoError(t, typ.Validate(), typ.Name)
+		require.True(t, typ.IsLibrary(), typ.Name)
+	}
+
+	// Changes to the returned types do not affect the library
+	types := user_config.LibraryTypes()
+	types[len(types)-1].Values[0].Value = "changed"
+	require.NotEqual(t, "changed", user_config.LibraryTypes()[len(types)-1].Values[0].Value)
+}
+
+func TestConfigValidate_LibraryTypes(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.yaml": `types:
+  - name: LogLevel
+    from: library
+    example: trace
+  - name: ByteSize
+    from: library
+  - name: Duration
+    from: library
+    type: int64
+  - name: Color
+    from: library
+  - name: Bytes
+    from: catalog
+groups:
+  - name: App
+    fields:
+      - name: Level
+        type: LogLevel
+        default: verbose
+      - name: Limit
+        type: ByteSize
+        default: 10MB
+      - name: Timeout
+        type: Durration
+      - name: API
+        type: URL
+`})
+
+	cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	require.NoError(t, err)
+
+	err = cfg.Validate()
+	require.ErrorContains(t, err, `invalid default value "verbose" for field "Level" in group "App": expected one of debug, info, warn, error`)
+	require.ErrorContains(t, err, `config.yaml:4:14: invalid example value "trace" for type "LogLevel": expected one of debug, info, warn, error`)
+	require.ErrorContains(t, err, `config.yaml:9:11: type "Duration" cannot declare both from and type`)
+	require.ErrorContains(t, err, `config.yaml:10:11: unknown library type "Color", expected one of Duration, URL, IP, ByteSize, LogLevel`)
+	require.ErrorContains(t, err, `config.yaml:13:11: unknown source "catalog" of type "Bytes", expected library`)
+	require.ErrorContains(t, err, `unknown type "Durration" for field "Timeout" in group "App", did you mean "Duration"?`)
+	require.NotContains(t, err.Error(), "Limit")
+
+	warnings := make([]string, 0, len(cfg.Warnings()))
+	for _, w := range cfg.Warnings() {
+		warnings = append(warnings, w.Message)
+	}
+
+	require.Contains(t, warnings,
+		`unknown type "URL" for field "API" in group "App" is not declared, declare it with from: library to use the library type`)
+}
@@ -0,0 +1,129 @@
+package user_config
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Marshal encodes the user_configuration as a YAML document.
+// Keys are written in the order of the struct fields, keys with empty values are omitted.
+func (c *Config) Marshal() ([]byte, error) {
+	node, err := marshalNode(reflect.ValueOf(c).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// marshalNode returns the YAML node of a value of the user_configuration structs.
+func marshalNode(v reflect.Value) (*yaml.Node, error) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			// Empty values of maps, e.g. profiles that override nothing
+			return &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}, nil
+		}
+
+		return marshalNode(v.Elem())
+	case reflect.Struct:
+		if value, ok := v.Interface().(TypeValue); ok && !value.HasDetails() {
+			// Values without details are written as plain values, e.g. values: [debug, info]
+			return marshalNode(reflect.ValueOf(value.Value))
+		}
+
+		node := &yaml.Node{Kind: yaml.MappingNode}
+
+		for i := range v.NumField() {
+			key, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+			if key == "" || key == "-" || v.Field(i).IsZero() {
+				continue
+			}
+
+			value, err := marshalNode(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+		}
+
+		return node, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			break
+		}
+
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+
+		for i := range v.Len() {
+			item, err := marshalNode(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, item)
+		}
+
+		if !slices.ContainsFunc(node.Content, func(item *yaml.Node) bool { return item.Kind != yaml.ScalarNode }) {
+			node.Style = yaml.FlowStyle
+		}
+
+		return node, nil
+	case reflect.Map:
+		if kind := v.Type().Elem().Kind(); kind != reflect.Pointer && kind != reflect.Struct {
+			break
+		}
+
+		node := &yaml.Node{Kind: yaml.MappingNode}
+
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+
+		for _, key := range keys {
+			value, err := marshalNode(v.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.String()}, value)
+		}
+
+		return node, nil
+	}
+
+	node := &yaml.Node{}
+	if err := node.Encode(v.Interface()); err != nil {
+		return nil, err
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		// Lists of scalars, e.g. renamed_from or values, are short enough for one line
+		node.Style = yaml.FlowStyle
+	case yaml.ScalarNode:
+		// Double quotes like in the documentation, e.g. type: "[]string"
+		if node.Style == yaml.SingleQuotedStyle {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+	}
+
+	return node, nil
+}
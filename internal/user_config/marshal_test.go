@@ -0,0 +1,60 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestConfig_Marshal(t *testing.T) {
+	t.Parallel()
+
+	content := `version: 2
+options:
+  go_package: config
+profiles: [dev, prod]
+types:
+  - name: Level
+    type: string
+    values: [debug, info]
+  - name: Mode
+    type: string
+    values:
+      - fast
+      - value: safe
+        description: Checks every step
+        deprecated: true
+groups:
+  - name: App
+    prefix: APP_
+    fields:
+      - name: Level
+        type: Level
+        default: info
+      - name: Hosts
+        type: "[]string"
+        separator: ;
+        example: a;b
+      - name: Port
+        type: int
+        profiles:
+          dev: {}
+          prod:
+            required: true
+        validate:
+          min: 1
+`
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.yaml": content})
+
+	cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	require.NoError(t, err)
+
+	data, err := cfg.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, content, string(data))
+}
@@ -0,0 +1,277 @@
+package user_config
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the newest version of the user_configuration format supported by envgen.
+// Files without the version key are treated as version 1.
+const CurrentVersion = 2
+
+// migration upgrades a user_configuration document to the next version of the format.
+type migration struct {
+	version int                            // Version produced by the migration
+	apply   func(root *yaml.Node) []string // Rewrites the document and describes every change
+}
+
+// migrations upgrade documents from version 1, in order.
+var migrations = []migration{
+	{version: 2, apply: migrateGoTagSeparators},
+}
+
+// GetVersion returns the version of the user_configuration format the file is written in.
+func (c *Config) GetVersion() int {
+	if c.Version == 0 {
+		return 1
+	}
+
+	return c.Version
+}
+
+// checkVersion reports files written in an unknown version of the format.
+func (c *Config) checkVersion() *Diagnostic {
+	switch {
+	case c.Version < 0:
+		return diagnosticf(c.pos.at("version"), "invalid configuration version %d", c.Version)
+	case c.Version > CurrentVersion:
+		return diagnosticf(c.pos.at("version"),
+			"configuration version %d is newer than the version %d supported by this envgen, upgrade envgen",
+			c.Version, CurrentVersion)
+	default:
+		return nil
+	}
+}
+
+// warnOutdatedVersion warns about files that declare an older version of the format.
+func (c *Config) warnOutdatedVersion() {
+	if c.Version != 0 && c.Version < CurrentVersion {
+		c.warnings = append(c.warnings, diagnosticf(c.pos.at("version"),
+			"configuration version %d is outdated, run envgen migrate to upgrade it to version %d",
+			c.Version, CurrentVersion))
+	}
+}
+
+// MigrateResult describes a migrated user_configuration document.
+type MigrateResult struct {
+	From    int      // Version of the original document
+	To      int      // Version of the migrated document
+	Changes []string // Descriptions of the changes
+	Data    []byte   // Migrated document
+}
+
+// Migrate upgrades a YAML user_configuration document to CurrentVersion.
+// The document is rewritten through yaml.Node, so comments and the order of keys are preserved.
+// Included files are not migrated. Returns an error if the document cannot be parsed
+// or is newer than CurrentVersion.
+func Migrate(data []byte) (*MigrateResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("configuration must be a mapping")
+	}
+
+	root := doc.Content[0]
+	result := &MigrateResult{From: 1, To: CurrentVersion, Data: data}
+
+	if node := mappingValue(root, "version"); node != nil {
+		version, err := strconv.Atoi(node.Value)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("line %d: invalid configuration version %q", node.Line, node.Value)
+		}
+
+		if version > CurrentVersion {
+			return nil, fmt.Errorf("line %d: configuration version %d is newer than the version %d supported by this envgen, upgrade envgen",
+				node.Line, version, CurrentVersion)
+		}
+
+		result.From = version
+	}
+
+	if result.From == CurrentVersion {
+		return result, nil
+	}
+
+	for _, m := range migrations {
+		if m.version > result.From {
+			result.Changes = append(result.Changes, m.apply(root)...)
+		}
+	}
+
+	setVersion(root, CurrentVersion)
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	result.Data = buf.Bytes()
+
+	return result, nil
+}
+
+// setVersion sets the version key of the document. A new key is placed first
+// and takes over the comment at the top of the document.
+func setVersion(root *yaml.Node, version int) {
+	if node := mappingValue(root, "version"); node != nil {
+		node.Value = strconv.Itoa(version)
+		node.Tag = "!!int"
+		node.Style = 0
+
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	if len(root.Content) > 0 {
+		key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+	}
+
+	root.Content = append([]*yaml.Node{
+		key,
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)},
+	}, root.Content...)
+}
+
+// migrateGoTagSeparators moves the envSeparator and envKeyValSeparator tags of the go_tags option
+// to the separator and key_value_separator keys of the field (version 2). Tags of fields that
+// already declare the keys are removed, since the keys take precedence.
+func migrateGoTagSeparators(root *yaml.Node) []string {
+	var changes []string
+
+	for _, group := range sequenceItems(mappingValue(root, "groups")) {
+		groupName := scalarValue(mappingValue(group, "name"))
+
+		for _, field := range sequenceItems(mappingValue(group, "fields")) {
+			options := mappingValue(field, "options")
+			tags := mappingValue(options, "go_tags")
+
+			if tags == nil || tags.Kind != yaml.ScalarNode {
+				continue
+			}
+
+			rest := tags.Value
+
+			for _, m := range goTagSeparator.FindAllStringSubmatch(tags.Value, -1) {
+				key := "separator"
+				if m[1] == "envKeyValSeparator" {
+					key = "key_value_separator"
+				}
+
+				fieldName := scalarValue(mappingValue(field, "name"))
+				rest = strings.Replace(rest, m[0], "", 1)
+
+				// The separator keys take precedence over the tags
+				switch existing := mappingValue(field, key); {
+				case existing == nil:
+					setMappingValue(field, key, m[2], "options")
+
+					changes = append(changes, fmt.Sprintf("field %q in group %q: moved %s from go_tags to %s",
+						fieldName, groupName, m[1], key))
+				case existing.Value == m[2]:
+					changes = append(changes, fmt.Sprintf("field %q in group %q: removed %s from go_tags, it duplicates %s",
+						fieldName, groupName, m[1], key))
+				default:
+					changes = append(changes, fmt.Sprintf("field %q in group %q: removed %s %q from go_tags, it conflicts with %s %q",
+						fieldName, groupName, m[1], m[2], key, existing.Value))
+				}
+			}
+
+			if rest = strings.Join(strings.Fields(rest), " "); rest != tags.Value {
+				tags.Value = rest
+				tags.Style = 0
+			}
+
+			if rest == "" {
+				deleteMappingKey(options, "go_tags")
+			}
+
+			if len(options.Content) == 0 {
+				deleteMappingKey(field, "options")
+			}
+		}
+	}
+
+	return changes
+}
+
+// mappingValue returns the value of the key in a mapping node.
+// Returns nil if the node is not a mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setMappingValue adds a string key to a mapping node before the key named before,
+// or at the end if the mapping has no such key.
+func setMappingValue(node *yaml.Node, key, value, before string) {
+	pair := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle},
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == before {
+			node.Content = slices.Insert(node.Content, i, pair...)
+
+			return
+		}
+	}
+
+	node.Content = append(node.Content, pair...)
+}
+
+// deleteMappingKey removes the key and its value from a mapping node.
+func deleteMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = slices.Delete(node.Content, i, i+2)
+
+			return
+		}
+	}
+}
+
+// sequenceItems returns the items of a sequence node.
+// Returns nil if the node is not a sequence.
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
+
+// scalarValue returns the value of a scalar node.
+// Returns an empty string if the node is not a scalar.
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
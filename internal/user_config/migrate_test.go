@@ -0,0 +1,219 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		want        string
+		wantFrom    int
+		wantChanges []string
+		wantError   string
+	}{
+		{
+			name: "go_tags separators",
+			content: `# Service configuration
+groups:
+  # Application settings
+  - name: App
+    fields:
+      - name: Tags
+        type: "[]string"
+        options:
+          go_tags: envSeparator:";" # custom separator
+      - name: Labels
+        type: map[string]string
+        options:
+          go_tags: envSeparator:";" envKeyValSeparator:"=" json:"labels"
+`,
+			want: `# Service configuration
+version: 2
+groups:
+  # Application settings
+  - name: App
+    fields:
+      - name: Tags
+        type: "[]string"
+        separator: ";"
+      - name: Labels
+        type: map[string]string
+        separator: ";"
+        key_value_separator: "="
+        options:
+          go_tags: json:"labels"
+`,
+			wantFrom: 1,
+			wantChanges: []string{
+				`field "Tags" in group "App": moved envSeparator from go_tags to separator`,
+				`field "Labels" in group "App": moved envSeparator from go_tags to separator`,
+				`field "Labels" in group "App": moved envKeyValSeparator from go_tags to key_value_separator`,
+			},
+		},
+		{
+			name: "go_tags separators with separator keys",
+			content: `groups:
+  - name: App
+    fields:
+      - name: Tags
+        type: "[]string"
+        separator: ";"
+        options:
+          go_tags: envSeparator:";" json:"tags"
+      - name: Labels
+        type: map[string]string
+        separator: ";"
+        key_value_separator: "="
+        options:
+          go_tags: envSeparator:"|" envKeyValSeparator:"="
+`,
+			want: `version: 2
+groups:
+  - name: App
+    fields:
+      - name: Tags
+        type: "[]string"
+        separator: ";"
+        options:
+          go_tags: json:"tags"
+      - name: Labels
+        type: map[string]string
+        separator: ";"
+        key_value_separator: "="
+`,
+			wantFrom: 1,
+			wantChanges: []string{
+				`field "Tags" in group "App": removed envSeparator from go_tags, it duplicates separator`,
+				`field "Labels" in group "App": removed envSeparator "|" from go_tags, it conflicts with separator ";"`,
+				`field "Labels" in group "App": removed envKeyValSeparator from go_tags, it duplicates key_value_separator`,
+			},
+		},
+		{
+			name: "explicit older version",
+			content: `version: 1
+groups:
+  - name: App
+    fields:
+      - name: Port
+        type: int
+`,
+			want: `version: 2
+groups:
+  - name: App
+    fields:
+      - name: Port
+        type: int
+`,
+			wantFrom: 1,
+		},
+		{
+			name: "current version",
+			content: `version: 2
+groups: []
+`,
+			want: `version: 2
+groups: []
+`,
+			wantFrom: 2,
+		},
+		{
+			name:      "newer version",
+			content:   "version: 99\ngroups: []\n",
+			wantError: "line 1: configuration version 99 is newer than the version 2 supported by this envgen, upgrade envgen",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := user_config.Migrate([]byte(tt.content))
+			if tt.wantError != "" {
+				require.EqualError(t, err, tt.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantFrom, result.From)
+			require.Equal(t, user_config.CurrentVersion, result.To)
+			require.Equal(t, tt.wantChanges, result.Changes)
+			require.Equal(t, tt.want, string(result.Data))
+		})
+	}
+}
+
+func TestConfigVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		wantVersion int
+		wantWarning string
+		wantError   string
+	}{
+		{
+			name:        "unversioned",
+			content:     "groups: [{name: App, fields: [{name: Port, type: int}]}]\n",
+			wantVersion: 1,
+		},
+		{
+			name:        "outdated",
+			content:     "version: 1\ngroups: [{name: App, fields: [{name: Port, type: int}]}]\n",
+			wantVersion: 1,
+			wantWarning: "config.yaml:1:10: configuration version 1 is outdated, run envgen migrate to upgrade it to version 2",
+		},
+		{
+			name:        "current",
+			content:     "version: 2\ngroups: [{name: App, fields: [{name: Port, type: int}]}]\n",
+			wantVersion: 2,
+		},
+		{
+			name:      "newer",
+			content:   "version: 3\ngroups: [{name: App, fields: [{name: Port, type: int, future: true}]}]\n",
+			wantError: "config.yaml:1:10: configuration version 3 is newer than the version 2 supported by this envgen, upgrade envgen",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, map[string]string{"config.yaml": tt.content})
+
+			cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+			if tt.wantError != "" {
+				require.ErrorContains(t, err, tt.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+			require.Equal(t, tt.wantVersion, cfg.GetVersion())
+
+			var warnings []string
+			for _, w := range cfg.Warnings() {
+				w.Pos.File = filepath.Base(w.Pos.File)
+				warnings = append(warnings, w.Error())
+			}
+
+			if tt.wantWarning == "" {
+				require.Empty(t, warnings)
+			} else {
+				require.Equal(t, []string{tt.wantWarning}, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package user_config
+
+import (
+	"slices"
+	"strings"
+)
+
+// Variable is an environment variable declared by a field of a group or of a nested group.
+type Variable struct {
+	Name  string // Full environment variable name including the prefixes of the enclosing groups
+	Field *Field // Field that declares the variable
+	Group *Group // Group that contains the field
+}
+
+// Variables returns the environment variables of the group in the order of its fields.
+// Group fields are expanded into the variables of the referenced group with composed prefixes,
+// e.g. a field of the group Webserver (prefix WEBSERVER_) referencing the group HealthConfig
+// (prefix HEALTH_) yields WEBSERVER_HEALTH_PORT.
+func (c *Config) Variables(group *Group) []Variable {
+	return c.variables(group, "", nil)
+}
+
+// variables returns the variables of the group nested with the prefix.
+// Groups that are already being expanded are skipped to stop reference cycles.
+func (c *Config) variables(group *Group, prefix string, expanding []string) []Variable {
+	if slices.Contains(expanding, group.Name) {
+		return nil
+	}
+
+	expanding = append(expanding, group.Name)
+	prefix += group.Prefix
+
+	var vars []Variable
+
+	for i := range group.Fields {
+		field := &group.Fields[i]
+
+		if field.Group == "" {
+			vars = append(vars, Variable{Name: field.EnvName(prefix), Field: field, Group: group})
+
+			continue
+		}
+
+		if nested := c.FindGroup(field.Group); nested != nil {
+			vars = append(vars, c.variables(nested, prefix, expanding)...)
+		}
+	}
+
+	return vars
+}
+
+// IsNested reports whether the group is referenced by a group field.
+// Variables of nested groups are declared by the groups that reference them.
+func (c *Config) IsNested(group *Group) bool {
+	for _, g := range c.Groups {
+		for _, f := range g.Fields {
+			if f.Group == group.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasRenamedVariables reports whether the group declares variables with previous names,
+// including the variables of the groups it nests. Nested groups declare no variables of their own,
+// so their previous names are applied by the groups that reference them.
+func (c *Config) HasRenamedVariables(group *Group) bool {
+	if c.IsNested(group) {
+		return false
+	}
+
+	return slices.ContainsFunc(c.Variables(group), func(v Variable) bool { return len(v.Field.RenamedFrom) > 0 })
+}
+
+// validateGroupRef checks that the group field refers to an existing group.
+func (c *Config) validateGroupRef(group *Group, field *Field) *Diagnostic {
+	if c.FindGroup(field.Group) != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(c.Groups))
+	for _, g := range c.Groups {
+		names = append(names, g.Name)
+	}
+
+	if suggestion := closestMatch(field.Group, names); suggestion != "" {
+		return diagnosticf(field.pos.at("group"), "unknown group %q for field %q in group %q, did you mean %q?",
+			field.Group, field.Name, group.Name, suggestion)
+	}
+
+	return diagnosticf(field.pos.at("group"), "unknown group %q for field %q in group %q",
+		field.Group, field.Name, group.Name)
+}
+
+// validateGroupCycles checks that groups do not reference themselves through group fields.
+// Every cycle is reported once, at the field that closes it.
+func (c *Config) validateGroupCycles() Diagnostics {
+	var diags Diagnostics
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(c.Groups))
+
+	var visit func(group *Group, path []string)
+	visit = func(group *Group, path []string) {
+		state[group.Name] = visiting
+		path = append(path, group.Name)
+
+		for i := range group.Fields {
+			field := &group.Fields[i]
+
+			nested := c.FindGroup(field.Group)
+			if field.Group == "" || nested == nil {
+				continue
+			}
+
+			switch state[nested.Name] {
+			case visiting:
+				cycle := slices.Concat(path[slices.Index(path, nested.Name):], []string{nested.Name})
+				diags = append(diags, diagnosticf(field.pos.at("group"),
+					"group reference cycle for field %q in group %q: %s",
+					field.Name, group.Name, strings.Join(cycle, " -> ")))
+			case unvisited:
+				visit(nested, path)
+			}
+		}
+
+		state[group.Name] = visited
+	}
+
+	for i := range c.Groups {
+		if state[c.Groups[i].Name] == unvisited {
+			visit(&c.Groups[i], nil)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,174 @@
+package user_config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestConfigVariables(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Groups: []user_config.Group{
+			{
+				Name:   "Webserver",
+				Prefix: "WEBSERVER_",
+				Fields: []user_config.Field{
+					{Name: "Port", Type: "int"},
+					{Name: "Health", Group: "HealthConfig"},
+					{Name: "Metrics", Group: "MetricsConfig"},
+				},
+			},
+			{
+				Name:   "HealthConfig",
+				Prefix: "HEALTH_",
+				Fields: []user_config.Field{{Name: "Port", Type: "int"}},
+			},
+			{
+				Name:   "MetricsConfig",
+				Prefix: "METRICS_",
+				Fields: []user_config.Field{
+					{Name: "Enabled", Type: "bool"},
+					{Name: "Health", Group: "HealthConfig"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var names []string
+	for _, variable := range cfg.Variables(&cfg.Groups[0]) {
+		names = append(names, variable.Name)
+	}
+
+	require.Equal(t, []string{
+		"WEBSERVER_PORT",
+		"WEBSERVER_HEALTH_PORT",
+		"WEBSERVER_METRICS_ENABLED",
+		"WEBSERVER_METRICS_HEALTH_PORT",
+	}, names)
+
+	health := cfg.Variables(&cfg.Groups[0])[1]
+	require.Same(t, &cfg.Groups[1].Fields[0], health.Field)
+	require.Same(t, &cfg.Groups[1], health.Group)
+
+	require.False(t, cfg.IsNested(&cfg.Groups[0]))
+	require.True(t, cfg.IsNested(&cfg.Groups[1]))
+	require.True(t, cfg.IsNested(&cfg.Groups[2]))
+}
+
+func TestConfigHasRenamedVariables(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Groups: []user_config.Group{
+			{Name: "Webserver", Prefix: "WEBSERVER_", Fields: []user_config.Field{{Name: "Health", Group: "HealthConfig"}}},
+			{
+				Name:   "HealthConfig",
+				Prefix: "HEALTH_",
+				Fields: []user_config.Field{{Name: "Port", Type: "int", RenamedFrom: []string{"WEBSERVER_HEALTH_CHECK_PORT"}}},
+			},
+			{Name: "App", Fields: []user_config.Field{{Name: "Name", Type: "string"}}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	require.True(t, cfg.HasRenamedVariables(&cfg.Groups[0]), "previous names of nested groups belong to the referencing group")
+	require.False(t, cfg.HasRenamedVariables(&cfg.Groups[1]), "nested groups declare no variables of their own")
+	require.False(t, cfg.HasRenamedVariables(&cfg.Groups[2]))
+	require.True(t, cfg.HasRenamedFields())
+}
+
+func TestConfigValidateGroupFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		groups  []user_config.Group
+		wantErr []string
+	}{
+		{
+			name: "unknown group",
+			groups: []user_config.Group{
+				{Name: "App", Fields: []user_config.Field{{Name: "Health", Group: "HealthConfg"}}},
+				{Name: "HealthConfig", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+			},
+			wantErr: []string{`unknown group "HealthConfg" for field "Health" in group "App", did you mean "HealthConfig"?`},
+		},
+		{
+			name: "type and group",
+			groups: []user_config.Group{
+				{Name: "App", Fields: []user_config.Field{{Name: "Health", Type: "int", Group: "HealthConfig"}}},
+				{Name: "HealthConfig", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+			},
+			wantErr: []string{`field "Health" cannot have both type and group`},
+		},
+		{
+			name: "default of group field",
+			groups: []user_config.Group{
+				{Name: "App", Fields: []user_config.Field{{Name: "Health", Group: "HealthConfig", Default: "on"}}},
+				{Name: "HealthConfig", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+			},
+			wantErr: []string{`group field "Health" cannot have default, example, profiles, validation rules or secret`},
+		},
+		{
+			name: "secret group field",
+			groups: []user_config.Group{
+				{Name: "App", Fields: []user_config.Field{{Name: "Health", Group: "HealthConfig", Secret: true}}},
+				{Name: "HealthConfig", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+			},
+			wantErr: []string{`group field "Health" cannot have default, example, profiles, validation rules or secret`},
+		},
+		{
+			name: "conditions of group field",
+			groups: []user_config.Group{
+				{Name: "App", Fields: []user_config.Field{{Name: "Health", Group: "HealthConfig", RequiredIf: "HEALTH_PORT"}}},
+				{Name: "HealthConfig", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+			},
+			wantErr: []string{`group field "Health" cannot have required_if, required_unless or conflicts_with`},
+		},
+		{
+			name: "reference cycle",
+			groups: []user_config.Group{
+				{Name: "A", Fields: []user_config.Field{{Name: "B", Group: "B"}}},
+				{Name: "B", Fields: []user_config.Field{{Name: "C", Group: "C"}}},
+				{Name: "C", Fields: []user_config.Field{{Name: "A", Group: "A"}}},
+			},
+			wantErr: []string{`group reference cycle for field "A" in group "C": A -> B -> C -> A`},
+		},
+		{
+			name: "self reference",
+			groups: []user_config.Group{
+				{Name: "A", Fields: []user_config.Field{{Name: "Port", Type: "int"}, {Name: "Self", Group: "A"}}},
+			},
+			wantErr: []string{`group reference cycle for field "Self" in group "A": A -> A`},
+		},
+		{
+			name: "collision of nested variables",
+			groups: []user_config.Group{
+				{Name: "App", Prefix: "APP_", Fields: []user_config.Field{
+					{Name: "HealthPort", Type: "int"},
+					{Name: "Health", Group: "HealthConfig"},
+				}},
+				{Name: "HealthConfig", Prefix: "HEALTH_", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+			},
+			wantErr: []string{`environment variable "APP_HEALTH_PORT" of field "Port" in group "HealthConfig" is also declared by field "HealthPort" in group "App"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &user_config.Config{Groups: tt.groups}
+
+			err := cfg.Validate()
+			for _, want := range tt.wantErr {
+				require.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
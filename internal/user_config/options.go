@@ -0,0 +1,95 @@
+package user_config
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/safeblock-dev/envgen/internal/template_funcs"
+)
+
+// Options holds the template-specific options of the configuration, a group or a field.
+// Values keep their YAML types: strings, booleans, numbers, lists and maps.
+// Maps nested in options, directly or in lists, are decoded as Options as well.
+type Options map[string]any
+
+// UnmarshalYAML decodes the options and converts the nested maps to Options.
+func (o *Options) UnmarshalYAML(node *yaml.Node) error {
+	var values map[string]any
+	if err := node.Decode(&values); err != nil {
+		return err
+	}
+
+	*o = nestedOptions(values).(Options)
+
+	return nil
+}
+
+// nestedOptions converts the maps in the option value to Options.
+func nestedOptions(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		options := make(Options, len(v))
+		for key, item := range v {
+			options[key] = nestedOptions(item)
+		}
+
+		return options
+	case []any:
+		for i, item := range v {
+			v[i] = nestedOptions(item)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// Has reports whether the option is set.
+func (o Options) Has(name string) bool {
+	_, ok := o[name]
+
+	return ok
+}
+
+// Get returns the value of the option as a string, the way options were read before they had types:
+// scalars are formatted as written, list items are joined with commas and map entries are written
+// as key:value pairs sorted by key. Returns an empty string if the option is not set.
+func (o Options) Get(name string) string {
+	return optionString(o[name])
+}
+
+// List returns the items of a list option as strings. A scalar option is returned as a single item.
+// Returns nil if the option is not set.
+func (o Options) List(name string) []string {
+	return template_funcs.ToList(o[name])
+}
+
+// optionString formats an option value as a string.
+func optionString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, optionString(item))
+		}
+
+		return strings.Join(items, ",")
+	case Options:
+		return optionString(map[string]any(v))
+	case map[string]any:
+		entries := make([]string, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			entries = append(entries, key+":"+optionString(v[key]))
+		}
+
+		return strings.Join(entries, ",")
+	default:
+		return template_funcs.ToString(v)
+	}
+}
@@ -0,0 +1,112 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	options := user_config.Options{
+		"go_name":         "Server",
+		"go_include":      true,
+		"md_hide":         "yes",
+		"go_skip_env_tag": false,
+		"retries":         3,
+		"ratio":           0.5,
+		"go_tags":         []any{`json:"port"`, `yaml:"port"`},
+		"labels":          map[string]any{"team": "core", "env": "prod"},
+		"empty":           "",
+	}
+
+	tests := []struct {
+		name     string
+		option   string
+		wantHas  bool
+		wantGet  string
+		wantList []string
+	}{
+		{name: "string", option: "go_name", wantHas: true, wantGet: "Server", wantList: []string{"Server"}},
+		{name: "bool", option: "go_include", wantHas: true, wantGet: "true", wantList: []string{"true"}},
+		{name: "bool string", option: "md_hide", wantHas: true, wantGet: "yes", wantList: []string{"yes"}},
+		{name: "false", option: "go_skip_env_tag", wantHas: true, wantGet: "false", wantList: []string{"false"}},
+		{name: "int", option: "retries", wantHas: true, wantGet: "3", wantList: []string{"3"}},
+		{name: "float", option: "ratio", wantHas: true, wantGet: "0.5", wantList: []string{"0.5"}},
+		{name: "list", option: "go_tags", wantHas: true, wantGet: `json:"port",yaml:"port"`, wantList: []string{`json:"port"`, `yaml:"port"`}},
+		{name: "map", option: "labels", wantHas: true, wantGet: "env:prod,team:core", wantList: []string{"map[env:prod team:core]"}},
+		{name: "empty", option: "empty", wantHas: true},
+		{name: "not set", option: "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.wantHas, options.Has(tt.option))
+			require.Equal(t, tt.wantGet, options.Get(tt.option))
+			require.Equal(t, tt.wantList, options.List(tt.option))
+		})
+	}
+
+	t.Run("nil options", func(t *testing.T) {
+		t.Parallel()
+
+		var options user_config.Options
+		require.False(t, options.Has("go_name"))
+		require.Empty(t, options.Get("go_name"))
+		require.Nil(t, options.List("go_name"))
+	})
+}
+
+func TestOptions_Decode(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.yaml": `options:
+  go_package: config
+  md_groups_hide_type: false
+groups:
+  - name: App
+    options:
+      go_name: AppConfig
+    fields:
+      - name: Port
+        type: int
+        options:
+          go_include: true
+          go_env_options: [file, notEmpty]
+          go_tags:
+            - json:"port"
+          weight: 1.5
+          labels:
+            team: core
+          matrix:
+            - os: linux
+`})
+
+	cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	require.Equal(t, user_config.Options{"go_package": "config", "md_groups_hide_type": false}, cfg.Options)
+	require.Equal(t, "false", cfg.GetOption("md_groups_hide_type"))
+	require.Equal(t, user_config.Options{"go_name": "AppConfig"}, cfg.Groups[0].Options)
+
+	options := cfg.Groups[0].Fields[0].Options
+	require.Equal(t, user_config.Options{
+		"go_include":     true,
+		"go_env_options": []any{"file", "notEmpty"},
+		"go_tags":        []any{`json:"port"`},
+		"weight":         1.5,
+		"labels":         user_config.Options{"team": "core"},
+		"matrix":         []any{user_config.Options{"os": "linux"}},
+	}, options)
+	require.Equal(t, "file,notEmpty", options.Get("go_env_options"))
+	require.Equal(t, []string{`json:"port"`}, options.List("go_tags"))
+	require.Equal(t, "team:core", options.Get("labels"))
+}
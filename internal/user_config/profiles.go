@@ -0,0 +1,237 @@
+package user_config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldProfile overrides the default value and the required flag of a field in a profile.
+// Values that are not set are inherited from the field.
+// Example:
+//
+//	profiles:
+//	  dev:
+//	    default: "false"   # Optional: Default value in the profile
+//	  prod:
+//	    required: true     # Optional: Whether the field is required in the profile
+type FieldProfile struct {
+	Default  *string `yaml:"default"`  // Optional: Default value in the profile
+	Required *bool   `yaml:"required"` // Optional: Whether the field is required in the profile
+
+	pos positions `yaml:"-"` // Locations of the overrides (not serialized)
+}
+
+// UnmarshalYAML decodes the profile overrides and records their location in the file.
+func (p *FieldProfile) UnmarshalYAML(node *yaml.Node) error {
+	type plain FieldProfile
+	if err := node.Decode((*plain)(p)); err != nil {
+		return err
+	}
+
+	p.pos = newPositions(node)
+
+	return nil
+}
+
+// ProfileValue is the effective default value and required flag of a field in a profile.
+type ProfileValue struct {
+	Profile  string // Profile name
+	Default  string // Effective default value
+	Required bool   // Effective required flag
+	Override bool   // Whether the field overrides any value in the profile
+}
+
+// Profile returns the effective values of the field in the profile.
+func (f *Field) Profile(name string) ProfileValue {
+	value := ProfileValue{Profile: name, Default: f.Default, Required: f.Required}
+
+	override, ok := f.Profiles[name]
+	if !ok || override == nil {
+		return value
+	}
+
+	if override.Default != nil {
+		value.Default = *override.Default
+		value.Override = true
+	}
+
+	if override.Required != nil {
+		value.Required = *override.Required
+		value.Override = true
+	}
+
+	return value
+}
+
+// HasProfiles checks if any field of the group overrides values in a profile.
+func (g *Group) HasProfiles() bool {
+	for _, field := range g.Fields {
+		if len(field.Profiles) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProfileValues returns the effective values of the field in every profile of the configuration.
+func (c *Config) ProfileValues(field *Field) []ProfileValue {
+	values := make([]ProfileValue, 0, len(c.Profiles))
+	for _, name := range c.Profiles {
+		values = append(values, field.Profile(name))
+	}
+
+	return values
+}
+
+// GetProfile returns the name of the applied profile.
+// Returns an empty string if no profile is applied.
+func (c *Config) GetProfile() string {
+	return c.profile
+}
+
+// ApplyProfile replaces the default values and required flags of all fields
+// with their effective values in the profile, so templates render the profile.
+// Returns an error if the profile is not declared in the configuration
+// or if a default value of the profile cannot be expanded or does not match the field type.
+func (c *Config) ApplyProfile(name string) error {
+	if !slices.Contains(c.Profiles, name) {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("unknown profile %q, the configuration declares no profiles", name)
+		}
+
+		if suggestion := closestMatch(name, c.Profiles); suggestion != "" {
+			return fmt.Errorf("unknown profile %q, did you mean %q?", name, suggestion)
+		}
+
+		return fmt.Errorf("unknown profile %q, available profiles: %s", name, strings.Join(c.Profiles, ", "))
+	}
+
+	return c.applyProfile(name).Err()
+}
+
+// applyProfile replaces the default values and required flags of all fields with their effective
+// values in the profile and expands the references in the default values. Returns a diagnostic
+// for every default value of the profile with an unknown or cyclic reference or whose expanded
+// value does not match the field type. Values without references are checked by validateFieldValues.
+func (c *Config) applyProfile(name string) Diagnostics {
+	for i := range c.Groups {
+		for j := range c.Groups[i].Fields {
+			field := &c.Groups[i].Fields[j]
+			value := field.Profile(name)
+
+			field.Default = value.Default
+			field.Required = value.Required
+		}
+	}
+
+	c.profile = name
+
+	// Profile default values may reference other variables
+	diags := c.expandValues()
+
+	for _, group := range c.Groups {
+		for _, field := range group.Fields {
+			override := field.Profiles[name]
+			if override == nil || override.Default == nil || !referencePattern.MatchString(field.Default) {
+				continue
+			}
+
+			value, ok := field.expanded[valueDefault]
+			if !ok || referencePattern.MatchString(value) {
+				continue
+			}
+
+			// Values of fields with invalid attributes or rules cannot be checked
+			goType := c.ResolveType(field.Type)
+			if len(field.validate()) > 0 || field.Constraints.ValidateKind(TypeKind(goType), bitSize(goType)) != nil {
+				continue
+			}
+
+			if err := c.checkFieldValue(&field, value); err != nil {
+				diags = append(diags, diagnosticf(override.pos.at("default"),
+					"invalid profile %q default value %q for field %q in group %q: %s",
+					name, value, field.Name, group.Name, err))
+			}
+		}
+	}
+
+	return diags
+}
+
+// validateProfileValues expands the default values of every profile on a copy of the configuration
+// and checks them against the field types.
+func (c *Config) validateProfileValues() Diagnostics {
+	var diags Diagnostics
+
+	for _, name := range slices.Compact(slices.Sorted(slices.Values(c.Profiles))) {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+
+		profiled := *c
+		profiled.Groups = slices.Clone(c.Groups)
+		profiled.expander = nil
+
+		for i := range profiled.Groups {
+			profiled.Groups[i].Fields = slices.Clone(c.Groups[i].Fields)
+		}
+
+		diags = append(diags, profiled.applyProfile(name)...)
+	}
+
+	return diags
+}
+
+// validateProfiles checks profile names for uniqueness.
+func (c *Config) validateProfiles() Diagnostics {
+	var diags Diagnostics
+
+	seen := make(map[string]struct{}, len(c.Profiles))
+
+	for _, name := range c.Profiles {
+		if strings.TrimSpace(name) == "" {
+			diags = append(diags, diagnosticf(c.pos.at("profiles"), "profile name is required"))
+
+			continue
+		}
+
+		if _, exists := seen[name]; exists {
+			diags = append(diags, diagnosticf(c.pos.at("profiles"), "duplicate profile name %q", name))
+		}
+
+		seen[name] = struct{}{}
+	}
+
+	return diags
+}
+
+// validateFieldProfiles checks that the field overrides values in declared profiles only.
+func (c *Config) validateFieldProfiles(group *Group, field *Field) Diagnostics {
+	var diags Diagnostics
+
+	for _, name := range slices.Sorted(maps.Keys(field.Profiles)) {
+		if slices.Contains(c.Profiles, name) {
+			continue
+		}
+
+		diag := diagnosticf(field.pos.at("profiles"), "unknown profile %q for field %q in group %q",
+			name, field.Name, group.Name)
+
+		if override := field.Profiles[name]; override != nil {
+			diag.Pos = override.pos.node
+		}
+
+		if suggestion := closestMatch(name, c.Profiles); suggestion != "" {
+			diag.Message += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+
+		diags = append(diags, diag)
+	}
+
+	return diags
+}
@@ -0,0 +1,232 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+const profilesConfig = `profiles: [dev, prod]
+groups:
+  - name: Database
+    prefix: DB_
+    fields:
+      - name: SSL
+        type: bool
+        default: "false"
+        profiles:
+          prod:
+            default: "true"
+            required: true
+      - name: Host
+        type: string
+        default: localhost
+        profiles:
+          dev: {}
+`
+
+func TestConfigApplyProfile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		profile   string
+		wantSSL   user_config.ProfileValue
+		wantHost  user_config.ProfileValue
+		wantError string
+	}{
+		{
+			name:     "dev",
+			profile:  "dev",
+			wantSSL:  user_config.ProfileValue{Profile: "dev", Default: "false"},
+			wantHost: user_config.ProfileValue{Profile: "dev", Default: "localhost"},
+		},
+		{
+			name:     "prod",
+			profile:  "prod",
+			wantSSL:  user_config.ProfileValue{Profile: "prod", Default: "true", Required: true, Override: true},
+			wantHost: user_config.ProfileValue{Profile: "prod", Default: "localhost"},
+		},
+		{
+			name:      "unknown profile",
+			profile:   "prd",
+			wantError: `unknown profile "prd", did you mean "prod"?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, map[string]string{"config.yaml": profilesConfig})
+
+			cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+
+			ssl, host := &cfg.Groups[0].Fields[0], &cfg.Groups[0].Fields[1]
+
+			err = cfg.ApplyProfile(tt.profile)
+			if tt.wantError != "" {
+				require.EqualError(t, err, tt.wantError)
+				require.Empty(t, cfg.GetProfile())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.profile, cfg.GetProfile())
+			require.Equal(t, tt.wantSSL.Default, ssl.Default)
+			require.Equal(t, tt.wantSSL.Required, ssl.Required)
+			require.Equal(t, tt.wantHost.Default, host.Default)
+			require.Equal(t, tt.wantHost.Required, host.Required)
+		})
+	}
+}
+
+func TestConfigApplyProfile_References(t *testing.T) {
+	t.Parallel()
+
+	cfg := &user_config.Config{
+		Profiles: []string{"prod"},
+		Groups: []user_config.Group{{Name: "App", Prefix: "APP_", Fields: []user_config.Field{
+			{Name: "Host", Type: "string", Default: "localhost"},
+			{Name: "A", Type: "string", Profiles: map[string]*user_config.FieldProfile{"prod": {Default: ptr("${APP_B}")}}},
+			{Name: "B", Type: "string", Profiles: map[string]*user_config.FieldProfile{"prod": {Default: ptr("${APP_A}")}}},
+			{Name: "URL", Type: "string", Profiles: map[string]*user_config.FieldProfile{"prod": {Default: ptr("http://${APP_HOST}")}}},
+		}}},
+	}
+
+	err := cfg.ApplyProfile("prod")
+	require.ErrorContains(t, err, `invalid profile "prod" default value "${APP_B}" for field "A" in group "App": reference cycle`)
+	require.Equal(t, "http://localhost", cfg.Groups[0].Fields[3].ExpandedDefault())
+}
+
+func TestConfigProfileValues(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.yaml": profilesConfig})
+
+	cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	require.NoError(t, err)
+
+	require.Equal(t, []user_config.ProfileValue{
+		{Profile: "dev", Default: "false"},
+		{Profile: "prod", Default: "true", Required: true, Override: true},
+	}, cfg.ProfileValues(&cfg.Groups[0].Fields[0]))
+}
+
+func TestConfigValidateProfiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "unknown and invalid profiles",
+			content: `profiles: [dev, prod, dev]
+groups:
+  - name: App
+    fields:
+      - name: port
+        type: int
+        profiles:
+          prod:
+            default: eighty
+          stagin:
+            required: true
+`,
+			want: []string{
+				`config.yaml:1:11: duplicate profile name "dev"`,
+				`config.yaml:9:22: invalid profile "prod" default value "eighty" for field "port" in group "App": expected int`,
+				`config.yaml:11:13: unknown profile "stagin" for field "port" in group "App"`,
+			},
+		},
+		{
+			name: "profile references",
+			content: `profiles: [prod]
+groups:
+  - name: App
+    prefix: APP_
+    fields:
+      - name: Host
+        type: string
+        default: localhost
+      - name: A
+        type: string
+        profiles:
+          prod:
+            default: ${APP_B}
+      - name: B
+        type: string
+        profiles:
+          prod:
+            default: ${APP_A}
+      - name: Port
+        type: int
+        profiles:
+          prod:
+            default: ${APP_HOST}
+      - name: URL
+        type: string
+        profiles:
+          prod:
+            default: http://${APP_HOTS}
+`,
+			want: []string{
+				`config.yaml:13:22: invalid profile "prod" default value "${APP_B}" for field "A" in group "App": reference cycle App.A -> App.B -> App.A`,
+				`config.yaml:18:22: invalid profile "prod" default value "${APP_A}" for field "B" in group "App": reference cycle App.B -> App.A -> App.B`,
+				`config.yaml:23:22: invalid profile "prod" default value "localhost" for field "Port" in group "App": expected int`,
+				`config.yaml:28:22: invalid profile "prod" default value "http://${APP_HOTS}" for field "URL" in group "App": unknown reference ${APP_HOTS}, did you mean ${APP_HOST}?`,
+			},
+		},
+		{
+			name: "unknown key in profile",
+			content: `profiles: [prod]
+groups:
+  - name: App
+    fields:
+      - name: port
+        type: int
+        profiles:
+          prod:
+            requried: true
+`,
+			want: []string{
+				`config.yaml:9:13: unknown key "requried" in profile "prod" of field "port" in group "App", did you mean "required"?`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, map[string]string{"config.yaml": tt.content})
+
+			cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+			if err == nil {
+				err = cfg.Validate()
+			}
+
+			diags := user_config.AsDiagnostics(err)
+			require.NotNil(t, diags)
+
+			got := make([]string, len(diags))
+			for i, diag := range diags {
+				diag.Pos.File = filepath.Base(diag.Pos.File)
+				got[i] = diag.Error()
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
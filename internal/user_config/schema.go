@@ -0,0 +1,318 @@
+package user_config
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SchemaURI identifies the JSON Schema dialect of the generated schema.
+const SchemaURI = "https://json-schema.org/draft/2020-12/schema"
+
+// Option scopes define where an option can be used.
+const (
+	ScopeConfig = "config" // Top-level options
+	ScopeGroup  = "group"  // Group options
+	ScopeField  = "field"  // Field options
+)
+
+// OptionInfo describes an option understood by the standard templates.
+type OptionInfo struct {
+	Name        string   // Option name
+	Description string   // Option description
+	Scopes      []string // Places where the option can be used
+	Flag        bool     // Whether the option is a boolean flag
+	List        bool     // Whether the option accepts a list of values
+}
+
+// KnownOptions lists the options used by the standard templates.
+// Templates may define their own options, so other options are allowed as well.
+var KnownOptions = []OptionInfo{
+	{Name: "go_package", Description: "Go package name of the generated file", Scopes: []string{ScopeConfig}},
+	{Name: "go_meta", Description: "Header of the generated Go file, an empty value disables the go:generate comment", Scopes: []string{ScopeConfig}},
+	{Name: "go_name", Description: "Go name of the generated struct or struct field", Scopes: []string{ScopeGroup, ScopeField}},
+	{Name: "go_skip_env_tag", Description: "Disables the generation of the env tag", Scopes: []string{ScopeConfig, ScopeGroup, ScopeField}, Flag: true},
+	{Name: "go_include", Description: "Embeds the field type into the struct", Scopes: []string{ScopeField}, Flag: true},
+	{Name: "go_env_options", Description: "Additional options of the env tag, e.g. file,notEmpty or [file, notEmpty]", Scopes: []string{ScopeField}, List: true},
+	{Name: "go_tags", Description: "Additional struct tags", Scopes: []string{ScopeField}, List: true},
+	{Name: "md_title", Description: "Title of the Markdown document", Scopes: []string{ScopeConfig}},
+	{Name: "md_description", Description: "Additional description in the Markdown document", Scopes: []string{ScopeConfig, ScopeGroup}},
+	{Name: "md_types_title", Description: "Title of the types section in the Markdown document", Scopes: []string{ScopeConfig}},
+	{Name: "md_types_description", Description: "Description of the types section in the Markdown document", Scopes: []string{ScopeConfig}},
+	{Name: "md_groups_hide_type", Description: "Hides the Type column of the groups tables", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_groups_hide_required", Description: "Hides the Required column of the groups tables", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_groups_hide_default", Description: "Hides the Default column of the groups tables", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_groups_hide_example", Description: "Hides the Example column of the groups tables", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_groups_hide_constraints", Description: "Hides the Constraints column of the groups tables", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_groups_hide_profiles", Description: "Hides the profile tables of the groups", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_groups_hide_description", Description: "Hides the Description column of the groups tables", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_types_hide_type", Description: "Hides the Type column of the types table", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_types_hide_import", Description: "Hides the Import column of the types table", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_types_hide_description", Description: "Hides the Description column of the types table", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_types_hide_values", Description: "Hides the Possible Values column of the types table", Scopes: []string{ScopeConfig}, Flag: true},
+	{Name: "md_hide", Description: "Hides the field in the Markdown document", Scopes: []string{ScopeConfig, ScopeGroup, ScopeField}, Flag: true},
+}
+
+// schemaDescriptions describes the keys of the user_configuration format by struct and key.
+var schemaDescriptions = map[string]string{
+	"Config.version":  "Version of the configuration format, upgraded with envgen migrate",
+	"Config.include":  "Files whose types, groups and options are merged in, relative to this file",
+	"Config.options":  "Template-specific options",
+	"Config.profiles": "Environment profiles whose values fields can override, e.g. dev, staging, prod",
+	"Config.types":    "Type definitions",
+	"Config.groups":   "Groups of environment variables",
+
+	"TypeDefinition.name":        "Type name for referencing in fields",
+	"TypeDefinition.from":        "Source of the definition, library for the predefined types Duration, URL, IP, ByteSize and LogLevel",
+	"TypeDefinition.type":        "Type definition (built-in or custom), unless taken from the library",
+	"TypeDefinition.kind":        "Kind of the type, enum generates a named type with a constant for each value",
+	"TypeDefinition.import":      "Import path for custom types",
+	"TypeDefinition.description": "Type description",
+	"TypeDefinition.example":     "Example value for fields without their own default or example",
+	"TypeDefinition.values":      "Possible values, plain or with descriptions",
+	"TypeValue.value":            "The value",
+	"TypeValue.description":      "Value description",
+	"TypeValue.deprecated":       "Whether the value is deprecated",
+
+	"Group.name":        "Group name",
+	"Group.description": "Group description",
+	"Group.prefix":      "Environment variable prefix",
+	"Group.tags":        "Tags for selecting the group and its fields with --include-tags and --exclude-tags",
+	"Group.options":     "Group-specific options",
+	"Group.fields":      "Fields of the group",
+
+	"Field.name":        "Environment variable name",
+	"Field.type":        "Field type (built-in type, defined or library type, or group)",
+	"Field.group":       "Group embedded as a nested struct, its prefix is appended to the prefix of this group",
+	"Field.description": "Field description",
+	"Field.default":     "Default value, may reference other variables as ${ENV_NAME} or ${Group.Field}, lists and maps may be written as YAML sequences and mappings",
+	"Field.required":    "Whether the field is required",
+	"Field.example":     "Example value for documentation, may reference other variables as ${ENV_NAME} or ${Group.Field}, lists and maps may be written as YAML sequences and mappings",
+	"Field.secret":      "Whether the value is sensitive, sensitive values are masked by the standard templates",
+	"Field.shared":      "Whether the environment variable is intentionally shared with other shared fields",
+
+	"Field.required_if":     "Condition under which the field is required: ENV_NAME, ENV_NAME=value or ENV_NAME!=value, variables may also be written as Group.Field",
+	"Field.required_unless": "Condition under which the field is not required, written as in required_if",
+	"Field.conflicts_with":  "Variables, as ENV_NAME or Group.Field, that must not be set together with the field",
+
+	"Field.separator":           "Separator of list elements and map entries, \",\" by default",
+	"Field.key_value_separator": "Separator of map keys and values, \":\" by default",
+
+	"Field.since":              "Version that introduced the variable",
+	"Field.deprecated":         "Whether the variable is deprecated",
+	"Field.deprecated_message": "Deprecation explanation, e.g. the replacement",
+	"Field.removed_in":         "Version that removes the variable",
+	"Field.renamed_from":       "Previous full environment variable names that are still accepted",
+	"Field.tags":               "Tags for selecting the field with --include-tags and --exclude-tags, added to the tags of the group",
+	"Field.profiles":           "Default values and required flags that override the field values by profile",
+	"Field.validate":           "Validation rules",
+	"Field.options":            "Field-specific options",
+
+	"FieldProfile.default":  "Default value in the profile",
+	"FieldProfile.required": "Whether the field is required in the profile",
+
+	"Constraints.min":     "Minimum numeric value",
+	"Constraints.max":     "Maximum numeric value",
+	"Constraints.min_len": "Minimum length of a string, list or map",
+	"Constraints.max_len": "Maximum length of a string, list or map",
+	"Constraints.pattern": "Regular expression the value must match",
+	"Constraints.values":  "Allowed values",
+	"Constraints.format":  "Value format",
+}
+
+// schemaRequired lists the required keys by struct.
+var schemaRequired = map[string][]string{
+	"Config":         {"groups"},
+	"TypeDefinition": {"name"},
+	"TypeValue":      {"value"},
+	"Group":          {"name", "fields"},
+	"Field":          {"name"},
+}
+
+// schemaAlternatives lists keys of which exactly one is required by struct.
+var schemaAlternatives = map[string][]string{
+	"TypeDefinition": {"type", "from"},
+	"Field":          {"type", "group"},
+}
+
+// schemaScalars lists string keys whose values may also be written as unquoted numbers or booleans.
+var schemaScalars = map[string]struct{}{
+	"Field.default":        {},
+	"Field.example":        {},
+	"FieldProfile.default": {},
+	"Constraints.values":   {},
+}
+
+// schemaCollections lists scalar keys whose values may also be written as sequences and mappings of scalars.
+var schemaCollections = map[string]struct{}{
+	"Field.default": {},
+	"Field.example": {},
+}
+
+// scalarSchema accepts any YAML scalar, all of them are decoded as strings.
+var scalarSchema = map[string]any{"type": []string{"string", "number", "boolean"}}
+
+// schemaScopes maps structs that have options to the scope of their options.
+var schemaScopes = map[string]string{
+	"Config": ScopeConfig,
+	"Group":  ScopeGroup,
+	"Field":  ScopeField,
+}
+
+// Schema returns a JSON Schema describing the user_configuration format.
+// The schema is built from the yaml tags of the user_configuration structs,
+// so it always matches the keys accepted by New.
+func Schema() map[string]any {
+	defs := make(map[string]any)
+
+	schema := structSchema(reflect.TypeFor[Config](), defs)
+	schema["$schema"] = SchemaURI
+	schema["title"] = "envgen configuration"
+	schema["$defs"] = defs
+
+	return schema
+}
+
+// SchemaJSON returns the JSON Schema describing the user_configuration format as indented JSON.
+func SchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// structSchema returns the schema of a user_configuration struct.
+// Schemas of nested structs are added to defs and referenced.
+func structSchema(t reflect.Type, defs map[string]any) map[string]any {
+	properties := make(map[string]any, t.NumField())
+
+	for i := range t.NumField() {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		var property map[string]any
+
+		switch scope, ok := schemaScopes[t.Name()]; {
+		case key == "options" && ok:
+			property = optionsSchema(scope)
+		case t.Name() == "TypeDefinition" && key == "values":
+			property = typeSchema(t.Field(i).Type, defs)
+			property["items"] = map[string]any{"anyOf": []any{scalarSchema, property["items"]}}
+		case t.Name() == "TypeDefinition" && key == "kind":
+			property = map[string]any{"type": "string", "enum": []string{EnumKind}}
+		case t.Name() == "Constraints" && key == "format":
+			property = map[string]any{
+				"type": "string",
+				"enum": []string{FormatURL, FormatEmail, FormatHostname, FormatPort, FormatDuration},
+			}
+		default:
+			property = typeSchema(t.Field(i).Type, defs)
+		}
+
+		if _, ok := schemaScalars[t.Name()+"."+key]; ok {
+			if property["type"] == "array" {
+				property["items"] = scalarSchema
+			} else {
+				property["type"] = scalarSchema["type"]
+			}
+		}
+
+		if _, ok := schemaCollections[t.Name()+"."+key]; ok {
+			property["type"] = append(slices.Clone(scalarSchema["type"].([]string)), "array", "object")
+			property["items"] = scalarSchema
+			property["additionalProperties"] = scalarSchema
+		}
+
+		if description, ok := schemaDescriptions[t.Name()+"."+key]; ok {
+			property["description"] = description
+		}
+
+		properties[key] = property
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	if required, ok := schemaRequired[t.Name()]; ok {
+		schema["required"] = required
+	}
+
+	if alternatives, ok := schemaAlternatives[t.Name()]; ok {
+		oneOf := make([]any, 0, len(alternatives))
+		for _, key := range alternatives {
+			oneOf = append(oneOf, map[string]any{"required": []string{key}})
+		}
+
+		schema["oneOf"] = oneOf
+	}
+
+	return schema
+}
+
+// typeSchema returns the schema of a Go type used in the user_configuration structs.
+func typeSchema(t reflect.Type, defs map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), defs)
+	case reflect.Struct:
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = nil // Reserve the name to stop recursion
+			defs[t.Name()] = structSchema(t, defs)
+		}
+
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), defs)}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+// optionsSchema returns the schema of the options available in the scope.
+// Unknown options are allowed with values of any type because templates may define their own options.
+func optionsSchema(scope string) map[string]any {
+	properties := make(map[string]any)
+
+	for _, option := range KnownOptions {
+		if !slices.Contains(option.Scopes, scope) {
+			continue
+		}
+
+		property := map[string]any{"description": option.Description}
+
+		switch {
+		case option.Flag:
+			property["type"] = []string{"boolean", "string"}
+		case option.List:
+			property["type"] = []string{"string", "array"}
+			property["items"] = map[string]any{"type": "string"}
+		default:
+			property["type"] = "string"
+		}
+
+		properties[option.Name] = property
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": true,
+	}
+}
@@ -0,0 +1,124 @@
+package user_config_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestSchemaJSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := user_config.SchemaJSON()
+	require.NoError(t, err)
+
+	var schema struct {
+		Schema     string                    `json:"$schema"`
+		Required   []string                  `json:"required"`
+		Properties map[string]map[string]any `json:"properties"`
+		Defs       map[string]struct {
+			Required             []string                  `json:"required"`
+			OneOf                []map[string][]string     `json:"oneOf"`
+			Properties           map[string]map[string]any `json:"properties"`
+			AdditionalProperties bool                      `json:"additionalProperties"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	require.Equal(t, user_config.SchemaURI, schema.Schema)
+	require.Equal(t, []string{"groups"}, schema.Required)
+	require.ElementsMatch(t, []string{"version", "include", "options", "profiles", "types", "groups"}, keys(schema.Properties))
+
+	tests := []struct {
+		def      string
+		required []string
+		keys     []string
+	}{
+		{
+			def:      "TypeDefinition",
+			required: []string{"name"},
+			keys:     []string{"name", "from", "type", "kind", "import", "description", "example", "values"},
+		},
+		{
+			def:      "TypeValue",
+			required: []string{"value"},
+			keys:     []string{"value", "description", "deprecated"},
+		},
+		{
+			def:      "Group",
+			required: []string{"name", "fields"},
+			keys:     []string{"name", "description", "prefix", "tags", "options", "fields"},
+		},
+		{
+			def:      "Field",
+			required: []string{"name"},
+			keys: []string{"name", "type", "group", "description", "default", "required",
+				"required_if", "required_unless", "conflicts_with", "separator", "key_value_separator", "example", "secret", "shared",
+				"since", "deprecated", "deprecated_message", "removed_in", "renamed_from", "tags", "profiles", "validate", "options",
+			},
+		},
+		{
+			def:  "FieldProfile",
+			keys: []string{"default", "required"},
+		},
+		{
+			def:  "Constraints",
+			keys: []string{"min", "max", "min_len", "max_len", "pattern", "values", "format"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.def, func(t *testing.T) {
+			t.Parallel()
+
+			def, ok := schema.Defs[tt.def]
+			require.True(t, ok)
+			require.False(t, def.AdditionalProperties)
+			require.Equal(t, tt.required, def.Required)
+			require.ElementsMatch(t, tt.keys, keys(def.Properties))
+
+			for key, property := range def.Properties {
+				require.NotEmpty(t, property["description"], "missing description of %s.%s", tt.def, key)
+			}
+		})
+	}
+
+	t.Run("alternative keys", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, []map[string][]string{{"required": {"type"}}, {"required": {"group"}}}, schema.Defs["Field"].OneOf)
+		require.Equal(t, []map[string][]string{{"required": {"type"}}, {"required": {"from"}}}, schema.Defs["TypeDefinition"].OneOf)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+
+		configOptions := schema.Properties["options"]["properties"].(map[string]any)
+		require.Contains(t, configOptions, "go_package")
+		require.Contains(t, configOptions, "md_groups_hide_type")
+		require.Contains(t, configOptions, "md_hide")
+		require.NotContains(t, configOptions, "go_name")
+
+		groupOptions := schema.Defs["Group"].Properties["options"]["properties"].(map[string]any)
+		require.Contains(t, groupOptions, "go_name")
+		require.Contains(t, groupOptions, "md_hide")
+		require.NotContains(t, groupOptions, "go_package")
+
+		fieldOptions := schema.Defs["Field"].Properties["options"]["properties"].(map[string]any)
+		require.Contains(t, fieldOptions, "go_include")
+		require.Contains(t, fieldOptions, "md_hide")
+	})
+}
+
+// keys returns the keys of a map.
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	return result
+}
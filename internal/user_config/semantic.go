@@ -0,0 +1,307 @@
+package user_config
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// builtinTypes lists predeclared Go types that can be used as field types without definition.
+var builtinTypes = map[string]struct{}{
+	"any": {}, "bool": {}, "byte": {}, "complex64": {}, "complex128": {}, "error": {},
+	"float32": {}, "float64": {}, "int": {}, "int8": {}, "int16": {}, "int32": {}, "int64": {},
+	"rune": {}, "string": {}, "uint": {}, "uint8": {}, "uint16": {}, "uint32": {}, "uint64": {},
+	"uintptr": {},
+}
+
+// validateTypes validates every type definition, checks type names for uniqueness
+// and checks the example values against the types.
+func (c *Config) validateTypes() Diagnostics {
+	var diags Diagnostics
+
+	seen := make(map[string]struct{}, len(c.Types))
+
+	for _, t := range c.Types {
+		if typeDiags := t.validate(); len(typeDiags) > 0 {
+			diags = append(diags, typeDiags...)
+
+			continue
+		}
+
+		if _, exists := seen[t.Name]; exists {
+			diags = append(diags, diagnosticf(t.pos.at("name"), "duplicate type name %q", t.Name))
+		}
+
+		if t.Example != "" {
+			if err := c.parseValue(t.Name, t.Example, (&Field{}).separators()); err != nil {
+				diags = append(diags, diagnosticf(t.pos.at("example"), "invalid example value %q for type %q: %s", t.Example, t.Name, err))
+			}
+		}
+
+		seen[t.Name] = struct{}{}
+	}
+
+	return diags
+}
+
+// validateGroupNames checks group names for uniqueness.
+func (c *Config) validateGroupNames() Diagnostics {
+	var diags Diagnostics
+
+	seen := make(map[string]struct{}, len(c.Groups))
+
+	for _, g := range c.Groups {
+		if g.Name == "" {
+			continue
+		}
+
+		if _, exists := seen[g.Name]; exists {
+			diags = append(diags, diagnosticf(g.pos.at("name"), "duplicate group name %q", g.Name))
+		}
+
+		seen[g.Name] = struct{}{}
+	}
+
+	return diags
+}
+
+// envVariable describes a field that declares an environment variable.
+type envVariable struct {
+	owner  string   // Human-readable description of the declaring field
+	pos    Position // Location of the declaration
+	shared bool     // Whether the field allows sharing the variable
+}
+
+// validateEnvNames checks that every environment variable is declared by one field only.
+// Overlapping prefixes may produce the same name in different groups, e.g. the prefix REDIS_
+// with the field StreamsURL and the prefix REDIS_STREAMS_ with the field URL.
+// Nested groups are checked with the composed prefixes of the groups that reference them.
+// Previous names of renamed fields are checked as well. A variable may be declared
+// several times if all declaring fields are shared.
+func (c *Config) validateEnvNames() Diagnostics {
+	var diags Diagnostics
+
+	seen := make(map[string]envVariable)
+
+	declare := func(name string, variable envVariable) {
+		existing, exists := seen[name]
+		if !exists {
+			seen[name] = variable
+
+			return
+		}
+
+		if existing.shared && variable.shared {
+			return
+		}
+
+		diags = append(diags, diagnosticf(variable.pos, "environment variable %q of %s is also declared by %s at %s",
+			name, variable.owner, existing.owner, existing.pos))
+	}
+
+	// Duplicate field names are reported by the group, so only the first field with a name is checked
+	first := make(map[[2]string]*Field)
+	renamed := make(map[*Field]struct{})
+
+	for i := range c.Groups {
+		// Variables of nested groups are declared by the groups that reference them
+		if c.IsNested(&c.Groups[i]) {
+			continue
+		}
+
+		for _, variable := range c.Variables(&c.Groups[i]) {
+			field, group := variable.Field, variable.Group
+
+			key := [2]string{group.Name, field.Name}
+			if f, exists := first[key]; exists && f != field {
+				continue
+			}
+
+			first[key] = field
+
+			// Fields of group types are nested structs rather than variables
+			if len(field.validate()) > 0 || c.isGroupType(field.Type) {
+				continue
+			}
+
+			owner := fmt.Sprintf("field %q in group %q", field.Name, group.Name)
+			declare(variable.Name, envVariable{owner: owner, pos: field.pos.at("name"), shared: field.Shared})
+
+			// Previous names are full names, so they are declared once even if the group is nested several times
+			if _, exists := renamed[field]; exists {
+				continue
+			}
+
+			renamed[field] = struct{}{}
+
+			for _, name := range field.RenamedFrom {
+				declare(name, envVariable{
+					owner:  owner + " (renamed_from)",
+					pos:    field.pos.at("renamed_from"),
+					shared: field.Shared,
+				})
+			}
+		}
+	}
+
+	return diags
+}
+
+// validateFieldType checks that the field type refers to a known type.
+// An unknown type similar to a defined type or group is reported as an error,
+// any other unknown type is reported as a warning because it may be declared
+// next to the generated code. Undeclared library types are reported as warnings
+// that suggest declaring them.
+func (c *Config) validateFieldType(group *Group, field *Field) *Diagnostic {
+	unknown := c.unknownType(field.Type)
+	if unknown == "" {
+		return nil
+	}
+
+	if libraryType(unknown) != nil {
+		c.warnings = append(c.warnings, diagnosticf(field.pos.at("type"),
+			"unknown type %q for field %q in group %q is not declared, declare it with from: %s to use the library type",
+			unknown, field.Name, group.Name, LibrarySource))
+
+		return nil
+	}
+
+	if suggestion := closestMatch(unknown, c.typeNames()); suggestion != "" {
+		return diagnosticf(field.pos.at("type"), "unknown type %q for field %q in group %q, did you mean %q?",
+			unknown, field.Name, group.Name, suggestion)
+	}
+
+	c.warnings = append(c.warnings, diagnosticf(field.pos.at("type"),
+		"unknown type %q for field %q in group %q is neither a built-in type, a defined type nor a group",
+		unknown, field.Name, group.Name))
+
+	return nil
+}
+
+// warnUnusedTypes adds a warning for every type that is not used by any field.
+func (c *Config) warnUnusedTypes() {
+	used := make(map[string]struct{})
+
+	for _, g := range c.Groups {
+		for _, f := range g.Fields {
+			// Split composite types such as []LogLevel or map[string]LogLevel into type names
+			for _, name := range strings.FieldsFunc(f.Type, isTypeSeparator) {
+				used[name] = struct{}{}
+			}
+		}
+	}
+
+	for _, t := range c.Types {
+		if _, ok := used[t.Name]; !ok && t.Name != "" {
+			c.warnings = append(c.warnings, diagnosticf(t.pos.at("name"), "type %q is not used by any field", t.Name))
+		}
+	}
+}
+
+// unknownType returns the first type name in a type expression that cannot be resolved.
+// Returns an empty string if all referenced types are known.
+func (c *Config) unknownType(typeExpr string) string {
+	typeExpr = strings.TrimSpace(typeExpr)
+
+	switch {
+	case typeExpr == "":
+		return ""
+	case strings.HasPrefix(typeExpr, "*"):
+		return c.unknownType(typeExpr[1:])
+	case strings.HasPrefix(typeExpr, "["):
+		end := strings.Index(typeExpr, "]")
+		if end < 0 {
+			return typeExpr
+		}
+
+		return c.unknownType(typeExpr[end+1:])
+	case strings.HasPrefix(typeExpr, "map["):
+		key, value, ok := splitMapType(typeExpr)
+		if !ok {
+			return typeExpr
+		}
+
+		if unknown := c.unknownType(key); unknown != "" {
+			return unknown
+		}
+
+		return c.unknownType(value)
+	case strings.Contains(typeExpr, "."):
+		// Qualified types are resolved by the Go compiler
+		return ""
+	}
+
+	if _, ok := builtinTypes[typeExpr]; ok {
+		return ""
+	}
+
+	if c.FindType(typeExpr) != nil || c.FindGroup(typeExpr) != nil {
+		return ""
+	}
+
+	// Groups renamed with go_name are referenced by their Go name
+	for _, g := range c.Groups {
+		if g.Options.Get("go_name") == typeExpr {
+			return ""
+		}
+	}
+
+	return typeExpr
+}
+
+// isGroupType reports whether the type expression refers to a group, possibly through a pointer.
+func (c *Config) isGroupType(typeExpr string) bool {
+	name := strings.TrimPrefix(strings.TrimSpace(typeExpr), "*")
+
+	for _, g := range c.Groups {
+		if g.Name == name || g.Options.Get("go_name") == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typeNames returns the names of all defined types, library types and groups.
+func (c *Config) typeNames() []string {
+	names := make([]string, 0, len(c.Types)+len(libraryTypes)+len(c.Groups))
+
+	for _, t := range c.Types {
+		names = append(names, t.Name)
+	}
+
+	for _, t := range libraryTypes {
+		names = append(names, t.Name)
+	}
+
+	for _, g := range c.Groups {
+		names = append(names, g.Name)
+	}
+
+	return names
+}
+
+// isTypeSeparator reports whether r separates type names in a type expression.
+func isTypeSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.'
+}
+
+// splitMapType splits a map type expression into key and value types.
+func splitMapType(typeExpr string) (string, string, bool) {
+	depth := 0
+
+	for i := len("map["); i < len(typeExpr); i++ {
+		switch typeExpr[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				return typeExpr[len("map["):i], typeExpr[i+1:], true
+			}
+
+			depth--
+		}
+	}
+
+	return "", "", false
+}
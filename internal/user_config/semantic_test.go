@@ -0,0 +1,161 @@
+package user_config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestConfigValidateSemantics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		cfg          *user_config.Config
+		wantErr      []string
+		wantWarnings []string
+	}{
+		{
+			name: "valid references",
+			cfg: &user_config.Config{
+				Types: []user_config.TypeDefinition{{Name: "LogLevel", Type: "string"}},
+				Groups: []user_config.Group{
+					{Name: "Health", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+					{
+						Name: "App",
+						Fields: []user_config.Field{
+							{Name: "Levels", Type: "[]LogLevel"},
+							{Name: "Labels", Type: "map[string][]LogLevel"},
+							{Name: "Health", Type: "*Health"},
+							{Name: "Timeout", Type: "time.Duration"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid type definition",
+			cfg: &user_config.Config{
+				Types:  []user_config.TypeDefinition{{Name: "LogLevel"}},
+				Groups: []user_config.Group{{Name: "App", Fields: []user_config.Field{{Name: "Level", Type: "LogLevel"}}}},
+			},
+			wantErr: []string{`type definition is required for type "LogLevel"`},
+		},
+		{
+			name: "duplicate types and groups",
+			cfg: &user_config.Config{
+				Types: []user_config.TypeDefinition{
+					{Name: "LogLevel", Type: "string"},
+					{Name: "LogLevel", Type: "int"},
+				},
+				Groups: []user_config.Group{
+					{Name: "App", Fields: []user_config.Field{{Name: "Level", Type: "LogLevel"}}},
+					{Name: "App", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+				},
+			},
+			wantErr: []string{`duplicate type name "LogLevel"`, `duplicate group name "App"`},
+		},
+		{
+			name: "misspelled type",
+			cfg: &user_config.Config{
+				Types: []user_config.TypeDefinition{{Name: "LogLevel", Type: "string"}},
+				Groups: []user_config.Group{
+					{
+						Name: "App",
+						Fields: []user_config.Field{
+							{Name: "Level", Type: "LogLvel"},
+							{Name: "Levels", Type: "[]loglevel"},
+						},
+					},
+				},
+			},
+			wantErr: []string{
+				`unknown type "LogLvel" for field "Level" in group "App", did you mean "LogLevel"?`,
+				`unknown type "loglevel" for field "Levels" in group "App", did you mean "LogLevel"?`,
+			},
+			wantWarnings: []string{`type "LogLevel" is not used by any field`},
+		},
+		{
+			name: "unknown type and unused type",
+			cfg: &user_config.Config{
+				Types: []user_config.TypeDefinition{{Name: "LogLevel", Type: "string"}},
+				Groups: []user_config.Group{
+					{Name: "App", Fields: []user_config.Field{{Name: "Handler", Type: "Handler"}}},
+				},
+			},
+			wantWarnings: []string{
+				`unknown type "Handler" for field "Handler" in group "App" is neither a built-in type, a defined type nor a group`,
+				`type "LogLevel" is not used by any field`,
+			},
+		},
+		{
+			name: "environment variable collision across groups",
+			cfg: &user_config.Config{
+				Groups: []user_config.Group{
+					{Name: "Redis", Prefix: "REDIS_", Fields: []user_config.Field{{Name: "StreamsUrl", Type: "string"}}},
+					{Name: "RedisStreams", Prefix: "REDIS_STREAMS_", Fields: []user_config.Field{{Name: "Url", Type: "string"}}},
+				},
+			},
+			wantErr: []string{
+				`environment variable "REDIS_STREAMS_URL" of field "Url" in group "RedisStreams" is also declared by field "StreamsUrl" in group "Redis"`,
+			},
+		},
+		{
+			name: "environment variable collision with previous name",
+			cfg: &user_config.Config{
+				Groups: []user_config.Group{
+					{Name: "App", Fields: []user_config.Field{
+						{Name: "Port", Type: "int"},
+						{Name: "HttpPort", Type: "int", RenamedFrom: []string{"PORT"}},
+					}},
+				},
+			},
+			wantErr: []string{
+				`environment variable "PORT" of field "HttpPort" in group "App" (renamed_from) is also declared by field "Port" in group "App"`,
+			},
+		},
+		{
+			name: "shared environment variable",
+			cfg: &user_config.Config{
+				Groups: []user_config.Group{
+					{Name: "Api", Fields: []user_config.Field{{Name: "LogLevel", Type: "string", Shared: true}}},
+					{Name: "Worker", Fields: []user_config.Field{{Name: "LogLevel", Type: "string", Shared: true}}},
+				},
+			},
+		},
+		{
+			name: "fields of group types are not variables",
+			cfg: &user_config.Config{
+				Groups: []user_config.Group{
+					{Name: "Health", Prefix: "HEALTH_", Fields: []user_config.Field{{Name: "Port", Type: "int"}}},
+					{Name: "Api", Fields: []user_config.Field{{Name: "Health", Type: "*Health"}}},
+					{Name: "Worker", Fields: []user_config.Field{{Name: "Health", Type: "Health"}}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+			}
+
+			for _, want := range tt.wantErr {
+				require.ErrorContains(t, err, want)
+			}
+
+			var warnings []string
+			for _, w := range tt.cfg.Warnings() {
+				warnings = append(warnings, w.Error())
+			}
+
+			require.Equal(t, tt.wantWarnings, warnings)
+		})
+	}
+}
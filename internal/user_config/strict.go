@@ -0,0 +1,89 @@
+package user_config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// checkUnknownKeys reports every key of the configuration, its types and their values, groups,
+// fields, profile overrides and validation rules that does not belong to the user_configuration format.
+// Keys of option maps are not checked because options are template-specific.
+func (c *Config) checkUnknownKeys() Diagnostics {
+	var diags Diagnostics
+
+	diags = append(diags, unknownKeys(c.pos, Config{}, "configuration")...)
+
+	for _, t := range c.Types {
+		diags = append(diags, unknownKeys(t.pos, TypeDefinition{}, fmt.Sprintf("type %q", t.Name))...)
+
+		for _, v := range t.Values {
+			diags = append(diags, unknownKeys(v.pos, TypeValue{}, fmt.Sprintf("value %q of type %q", v.Value, t.Name))...)
+		}
+	}
+
+	for _, g := range c.Groups {
+		diags = append(diags, unknownKeys(g.pos, Group{}, fmt.Sprintf("group %q", g.Name))...)
+
+		for _, f := range g.Fields {
+			context := fmt.Sprintf("field %q in group %q", f.Name, g.Name)
+			diags = append(diags, unknownKeys(f.pos, Field{}, context)...)
+
+			if f.Constraints != nil {
+				diags = append(diags, unknownKeys(f.Constraints.pos, Constraints{},
+					"validation rules of "+context)...)
+			}
+
+			for _, name := range slices.Sorted(maps.Keys(f.Profiles)) {
+				if profile := f.Profiles[name]; profile != nil {
+					diags = append(diags, unknownKeys(profile.pos, FieldProfile{},
+						fmt.Sprintf("profile %q of %s", name, context))...)
+				}
+			}
+		}
+	}
+
+	diags.sort()
+
+	return diags
+}
+
+// unknownKeys reports keys of a YAML mapping that are not declared by the yaml tags of model.
+// A similar known key is suggested for every unknown key.
+func unknownKeys(pos positions, model any, context string) Diagnostics {
+	known := yamlKeys(reflect.TypeOf(model))
+
+	var diags Diagnostics
+
+	for _, key := range pos.order {
+		if _, ok := known[key]; ok {
+			continue
+		}
+
+		diag := diagnosticf(pos.names[key], "unknown key %q in %s", key, context)
+
+		if suggestion := closestMatch(key, slices.Sorted(maps.Keys(known))); suggestion != "" {
+			diag.Message += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+
+		diags = append(diags, diag)
+	}
+
+	return diags
+}
+
+// yamlKeys returns the keys declared by the yaml tags of a struct type.
+func yamlKeys(t reflect.Type) map[string]struct{} {
+	keys := make(map[string]struct{}, t.NumField())
+
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			keys[name] = struct{}{}
+		}
+	}
+
+	return keys
+}
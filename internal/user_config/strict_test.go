@@ -0,0 +1,137 @@
+package user_config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestNewUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		opts    user_config.LoadOptions
+		wantErr []string
+	}{
+		{
+			name: "typos in field",
+			files: map[string]string{"config.yaml": `groups:
+  - name: App
+    fields:
+      - name: port
+        type: int
+        requried: true
+        deafult: "8080"
+`},
+			wantErr: []string{
+				`config.yaml:6:9: unknown key "requried" in field "port" in group "App", did you mean "required"?`,
+				`config.yaml:7:9: unknown key "deafult" in field "port" in group "App", did you mean "default"?`,
+			},
+		},
+		{
+			name: "unknown keys at every level",
+			files: map[string]string{"config.yaml": `option:
+  go_package: config
+types:
+  - name: LogLevel
+    type: string
+    value: [debug, info]
+groups:
+  - name: App
+    prefixx: APP_
+    fields:
+      - name: level
+        type: LogLevel
+        validate:
+          min_length: 3
+`},
+			wantErr: []string{
+				`config.yaml:1:1: unknown key "option" in configuration, did you mean "options"?`,
+				`config.yaml:6:5: unknown key "value" in type "LogLevel", did you mean "values"?`,
+				`config.yaml:9:5: unknown key "prefixx" in group "App", did you mean "prefix"?`,
+				`config.yaml:14:11: unknown key "min_length" in validation rules of field "level" in group "App", did you mean "min_len"?`,
+			},
+		},
+		{
+			name: "unknown key in included file",
+			files: map[string]string{
+				"config.yaml": `include: [shared.yaml]
+groups:
+  - name: App
+    fields:
+      - name: port
+        type: int
+`,
+				"shared.yaml": `groups:
+  - name: Redis
+    fields:
+      - name: url
+        type: string
+        sensitive: true
+`,
+			},
+			wantErr: []string{`shared.yaml:6:9: unknown key "sensitive" in field "url" in group "Redis"`},
+		},
+		{
+			name: "unknown keys allowed",
+			files: map[string]string{"config.yaml": `groups:
+  - name: App
+    fields:
+      - name: port
+        type: int
+        requried: true
+`},
+			opts: user_config.LoadOptions{AllowUnknownKeys: true},
+		},
+		{
+			name: "options are not checked",
+			files: map[string]string{"config.yaml": `options:
+  custom_option: value
+groups:
+  - name: App
+    options:
+      custom_option: value
+    fields:
+      - name: port
+        type: int
+        options:
+          custom_option: value
+`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, tt.files)
+
+			_, err := user_config.NewWithOptions(filepath.Join(tmpDir, "config.yaml"), tt.opts)
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			diags := user_config.AsDiagnostics(err)
+			require.NotNil(t, diags)
+
+			got := make([]string, len(diags))
+			for i, diag := range diags {
+				rel, relErr := filepath.Rel(tmpDir, diag.Pos.File)
+				require.NoError(t, relErr)
+
+				diag.Pos.File = rel
+				got[i] = diag.Error()
+			}
+
+			require.Equal(t, tt.wantErr, got)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package user_config
+
+import "strings"
+
+// closestMatch returns the candidate most similar to name.
+// Returns an empty string if no candidate is similar enough to be a likely typo.
+func closestMatch(name string, candidates []string) string {
+	var (
+		best     string
+		bestDist = maxTypoDistance(name) + 1
+	)
+
+	for _, candidate := range candidates {
+		if candidate == name {
+			continue
+		}
+
+		dist := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if dist < bestDist {
+			best, bestDist = candidate, dist
+		}
+	}
+
+	return best
+}
+
+// maxTypoDistance returns the maximum edit distance considered a typo for a name.
+func maxTypoDistance(name string) int {
+	return max(1, len([]rune(name))/3)
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,115 @@
+package user_config
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v3"
+)
+
+// tomlErrorPosition extracts the location and the message from errors reported by the TOML parser.
+var tomlErrorPosition = regexp.MustCompile(`^\((\d+), (\d+)\): (.*)$`)
+
+// parseTOML parses a TOML document into a YAML node.
+func parseTOML(data []byte) (*yaml.Node, error) {
+	tree, err := toml.LoadBytes(data)
+	if err != nil {
+		if m := tomlErrorPosition.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			column, _ := strconv.Atoi(m[2])
+
+			return nil, diagnosticf(Position{Line: line, Column: column}, "%s", m[3])
+		}
+
+		return nil, err
+	}
+
+	return tomlTreeNode(tree, toml.Position{Line: 1, Col: 1}), nil
+}
+
+// tomlTreeNode converts a TOML table into a YAML mapping node.
+// Inline tables have no location, the location of the enclosing key is used instead.
+func tomlTreeNode(tree *toml.Tree, fallback toml.Position) *yaml.Node {
+	pos := tree.Position()
+	if pos.Invalid() {
+		pos = fallback
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: pos.Line, Column: pos.Col}
+
+	keyPositions := make(map[string]toml.Position)
+
+	for _, key := range tree.Keys() {
+		keyPos := tree.GetPositionPath([]string{key})
+		if keyPos.Invalid() {
+			keyPos = pos
+		}
+
+		keyPositions[key] = keyPos
+	}
+
+	// Keep the order of the document, the tree stores keys in a map
+	keys := tree.Keys()
+	slices.SortStableFunc(keys, func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(keyPositions[a].Line, keyPositions[b].Line),
+			cmp.Compare(keyPositions[a].Col, keyPositions[b].Col),
+			cmp.Compare(a, b),
+		)
+	})
+
+	for _, key := range keys {
+		keyPos := keyPositions[key]
+
+		node.Content = append(node.Content, &yaml.Node{
+			Kind:   yaml.ScalarNode,
+			Tag:    "!!str",
+			Value:  key,
+			Line:   keyPos.Line,
+			Column: keyPos.Col,
+		}, tomlValueNode(tree.GetPath([]string{key}), keyPos))
+	}
+
+	return node
+}
+
+// tomlValueNode converts a TOML value into a YAML node located at pos.
+func tomlValueNode(value any, pos toml.Position) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Line: pos.Line, Column: pos.Col}
+
+	switch value := value.(type) {
+	case *toml.Tree:
+		return tomlTreeNode(value, pos)
+	case []*toml.Tree:
+		node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+		for _, item := range value {
+			node.Content = append(node.Content, tomlTreeNode(item, pos))
+		}
+	case []any:
+		node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+		for _, item := range value {
+			node.Content = append(node.Content, tomlValueNode(item, pos))
+		}
+	case string:
+		node.Tag, node.Value = "!!str", value
+	case int64:
+		node.Tag, node.Value = "!!int", strconv.FormatInt(value, 10)
+	case uint64:
+		node.Tag, node.Value = "!!int", strconv.FormatUint(value, 10)
+	case float64:
+		node.Tag, node.Value = "!!float", strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		node.Tag, node.Value = "!!bool", strconv.FormatBool(value)
+	case time.Time:
+		node.Tag, node.Value = "!!str", value.Format(time.RFC3339Nano)
+	default:
+		node.Tag, node.Value = "!!str", fmt.Sprint(value)
+	}
+
+	return node
+}
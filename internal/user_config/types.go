@@ -1,10 +1,17 @@
 package user_config
 
 import (
-	"errors"
-	"fmt"
+	"go/token"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/safeblock-dev/envgen/internal/template_funcs"
 )
 
+// EnumKind is the kind of type definitions that generate a named Go type with a constant for each value.
+const EnumKind = "enum"
+
 // TypeDefinition describes a type and its possible values.
 // Example:
 //
@@ -14,12 +21,111 @@ import (
 //	    description: Log level          # Optional: Type description
 //	    import: "github.com/rs/zerolog" # Optional: Import path for custom types
 //	    values: [debug, info, no]       # Optional: Possible values for documentation
+//	  - name: Mode
+//	    type: string
+//	    values:                         # Optional: Values may have descriptions
+//	      - value: fast
+//	        description: Skips the checks
+//	        deprecated: true
+//	  - name: Environment
+//	    type: string
+//	    kind: enum                      # Optional: Generate a named type with constants for the values
+//	    values: [development, production]
+//	  - name: Duration
+//	    from: library                   # Optional: Take the definition from the library of predefined types
 type TypeDefinition struct {
-	Name        string   `yaml:"name"`        // Required: Type name for referencing in fields
-	Type        string   `yaml:"type"`        // Required: Type definition (built-in or custom)
-	Import      string   `yaml:"import"`      // Optional: Import path for custom types
-	Description string   `yaml:"description"` // Optional: Type description
-	Values      []string `yaml:"values"`      // Optional: Possible values for documentation
+	Name        string      `yaml:"name"`        // Required: Type name for referencing in fields
+	From        string      `yaml:"from"`        // Optional: Source of the definition, library for predefined types
+	Type        string      `yaml:"type"`        // Required: Type definition (built-in or custom), unless taken from the library
+	Kind        string      `yaml:"kind"`        // Optional: Kind of the type, enum generates a named type with constants
+	Import      string      `yaml:"import"`      // Optional: Import path for custom types
+	Description string      `yaml:"description"` // Optional: Type description
+	Example     string      `yaml:"example"`     // Optional: Example value for fields without their own default or example
+	Values      []TypeValue `yaml:"values"`      // Optional: Possible values, plain or with descriptions
+
+	source  string    `yaml:"-"` // Path to the file that declares the type (not serialized)
+	pos     positions `yaml:"-"` // Locations of the type and its values (not serialized)
+	library bool      `yaml:"-"` // Whether the definition is taken from the library (not serialized)
+}
+
+// TypeValue is a possible value of a type. It is written either as a plain value
+// or as a mapping with a description and a deprecation flag.
+type TypeValue struct {
+	Value       string `yaml:"value"`       // Required: The value
+	Description string `yaml:"description"` // Optional: Value description
+	Deprecated  bool   `yaml:"deprecated"`  // Optional: Whether the value is deprecated
+
+	pos positions `yaml:"-"` // Locations of the value and its keys (not serialized)
+}
+
+// UnmarshalYAML decodes a plain value or a mapping and records its location in the file.
+func (v *TypeValue) UnmarshalYAML(node *yaml.Node) error {
+	v.pos = newPositions(node)
+
+	if node.Kind == yaml.ScalarNode {
+		v.Value = node.Value
+
+		return nil
+	}
+
+	type plain TypeValue
+
+	return node.Decode((*plain)(v))
+}
+
+// String returns the value.
+func (v TypeValue) String() string {
+	return v.Value
+}
+
+// HasDetails reports whether the value has a description or is deprecated.
+func (v TypeValue) HasDetails() bool {
+	return v.Description != "" || v.Deprecated
+}
+
+// Note returns the description of the value with a deprecation mark,
+// e.g. "Skips the checks (deprecated)". Returns an empty string if the value has no details.
+func (v TypeValue) Note() string {
+	switch {
+	case !v.Deprecated:
+		return v.Description
+	case v.Description == "":
+		return "Deprecated"
+	default:
+		return v.Description + " (deprecated)"
+	}
+}
+
+// UnmarshalYAML decodes the type definition and records its location in the file.
+func (t *TypeDefinition) UnmarshalYAML(node *yaml.Node) error {
+	type plain TypeDefinition
+	if err := node.Decode((*plain)(t)); err != nil {
+		return err
+	}
+
+	t.pos = newPositions(node)
+
+	return nil
+}
+
+// GetSource returns the path to the file that declares the type.
+func (t *TypeDefinition) GetSource() string {
+	return t.source
+}
+
+// setFile sets the file of the locations of the type.
+func (t *TypeDefinition) setFile(file string) {
+	t.source = file
+	t.pos.setFile(file)
+
+	for i := range t.Values {
+		t.Values[i].pos.setFile(file)
+	}
+}
+
+// GetPosition returns the location of the type in the file that declares it.
+func (t *TypeDefinition) GetPosition() Position {
+	return t.pos.node
 }
 
 // HasValues checks if the type has predefined values.
@@ -28,16 +134,125 @@ func (t *TypeDefinition) HasValues() bool {
 	return len(t.Values) > 0
 }
 
+// ValueNames returns the possible values of the type without their descriptions.
+func (t *TypeDefinition) ValueNames() []string {
+	if len(t.Values) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(t.Values))
+	for _, v := range t.Values {
+		names = append(names, v.Value)
+	}
+
+	return names
+}
+
+// HasValue checks if the value is one of the possible values of the type.
+func (t *TypeDefinition) HasValue(value string) bool {
+	return slices.Contains(t.ValueNames(), value)
+}
+
+// HasValueDetails checks if any possible value of the type has a description or is deprecated.
+func (t *TypeDefinition) HasValueDetails() bool {
+	return slices.ContainsFunc(t.Values, TypeValue.HasDetails)
+}
+
+// IsEnum reports whether the type is an enum with a generated constant for each value.
+func (t *TypeDefinition) IsEnum() bool {
+	return t.Kind == EnumKind
+}
+
+// ConstName returns the name of the Go constant of the enum value: the type name followed by
+// the value in PascalCase, e.g. LogLevelDebug for the value debug of the type LogLevel.
+func (t *TypeDefinition) ConstName(value string) string {
+	return t.Name + template_funcs.ToPascalCase(value)
+}
+
 // Validate validates the type definition.
 // Returns an error if required fields are missing.
 func (t *TypeDefinition) Validate() error {
+	return t.validate().Err()
+}
+
+// validate returns every problem of the type definition.
+func (t *TypeDefinition) validate() Diagnostics {
+	var diags Diagnostics
+
+	if diag := t.validateSource(); diag != nil {
+		return Diagnostics{diag}
+	}
+
+	resolved := t.resolved()
+	t = &resolved
+
 	if t.Name == "" {
-		return errors.New("type name is required")
+		diags = append(diags, diagnosticf(t.pos.node, "type name is required"))
 	}
 
 	if t.Type == "" {
-		return fmt.Errorf("type definition is required for type %q", t.Name)
+		diags = append(diags, diagnosticf(t.pos.node, "type definition is required for type %q", t.Name))
 	}
 
-	return nil
+	for _, v := range t.Values {
+		// Mappings must declare the value, plain values may be empty strings
+		if v.Value == "" && (v.HasDetails() || len(v.pos.order) > 0) {
+			diags = append(diags, diagnosticf(v.pos.at("value"), "value of type %q is required", t.Name))
+		}
+	}
+
+	switch t.Kind {
+	case "":
+	case EnumKind:
+		diags = append(diags, t.validateEnum()...)
+	default:
+		diags = append(diags, diagnosticf(t.pos.at("kind"), "unknown kind %q of type %q, expected %s", t.Kind, t.Name, EnumKind))
+	}
+
+	return diags
+}
+
+// validateEnum checks that the enum type can be generated: the name is a Go identifier,
+// the underlying type is string and every value has a distinct constant name.
+func (t *TypeDefinition) validateEnum() Diagnostics {
+	var diags Diagnostics
+
+	if t.Name != "" && !token.IsIdentifier(t.Name) {
+		diags = append(diags, diagnosticf(t.pos.at("name"), "enum type name %q is not a valid Go identifier", t.Name))
+	}
+
+	if t.Type != "" && TypeKind(t.Type) != KindString {
+		diags = append(diags, diagnosticf(t.pos.at("type"), "enum type %q must have the string type, got %s", t.Name, t.Type))
+	}
+
+	if t.Import != "" {
+		diags = append(diags, diagnosticf(t.pos.at("import"), "enum type %q is generated and cannot have an import", t.Name))
+	}
+
+	if len(t.Values) == 0 {
+		diags = append(diags, diagnosticf(t.pos.at("kind"), "enum type %q requires values", t.Name))
+	}
+
+	seen := make(map[string]string, len(t.Values))
+
+	for _, value := range t.ValueNames() {
+		if template_funcs.ToPascalCase(value) == "" {
+			diags = append(diags, diagnosticf(t.pos.at("values"),
+				"value %q of enum type %q has no letters or digits for a constant name", value, t.Name))
+
+			continue
+		}
+
+		name := t.ConstName(value)
+		if other, ok := seen[name]; ok {
+			diags = append(diags, diagnosticf(t.pos.at("values"),
+				"values %q and %q of enum type %q have the same constant name %s", other, value, t.Name, name))
+
+			continue
+		}
+
+		seen[name] = value
+	}
+
+	return diags
 }
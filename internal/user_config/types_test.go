@@ -1,6 +1,7 @@
 package user_config_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,11 +14,11 @@ func TestHasValues(t *testing.T) {
 
 	tests := []struct {
 		name     string
-		values   []string
+		values   []user_config.TypeValue
 		expected bool
 	}{
-		{name: "with values", values: []string{"debug", "info"}, expected: true},
-		{name: "empty values", values: []string{}, expected: false},
+		{name: "with values", values: []user_config.TypeValue{{Value: "debug"}, {Value: "info"}}, expected: true},
+		{name: "empty values", values: []user_config.TypeValue{}, expected: false},
 		{name: "nil values", values: nil, expected: false},
 	}
 
@@ -82,3 +83,148 @@ func TestTypeDefinitionValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeDefinition_Enum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		td      user_config.TypeDefinition
+		wantErr string
+	}{
+		{
+			name: "valid enum",
+			td:   user_config.TypeDefinition{Name: "LogLevel", Type: "string", Kind: "enum", Values: []user_config.TypeValue{{Value: "debug"}, {Value: "info"}}},
+		},
+		{
+			name:    "unknown kind",
+			td:      user_config.TypeDefinition{Name: "LogLevel", Type: "string", Kind: "set", Values: []user_config.TypeValue{{Value: "debug"}}},
+			wantErr: `unknown kind "set" of type "LogLevel", expected enum`,
+		},
+		{
+			name:    "without values",
+			td:      user_config.TypeDefinition{Name: "LogLevel", Type: "string", Kind: "enum"},
+			wantErr: `enum type "LogLevel" requires values`,
+		},
+		{
+			name:    "not a string",
+			td:      user_config.TypeDefinition{Name: "Level", Type: "int", Kind: "enum", Values: []user_config.TypeValue{{Value: "1"}}},
+			wantErr: `enum type "Level" must have the string type, got int`,
+		},
+		{
+			name:    "with import",
+			td:      user_config.TypeDefinition{Name: "LogLevel", Type: "string", Kind: "enum", Import: "log/slog", Values: []user_config.TypeValue{{Value: "debug"}}},
+			wantErr: `enum type "LogLevel" is generated and cannot have an import`,
+		},
+		{
+			name:    "invalid name",
+			td:      user_config.TypeDefinition{Name: "log-level", Type: "string", Kind: "enum", Values: []user_config.TypeValue{{Value: "debug"}}},
+			wantErr: `enum type name "log-level" is not a valid Go identifier`,
+		},
+		{
+			name:    "value without letters",
+			td:      user_config.TypeDefinition{Name: "Sign", Type: "string", Kind: "enum", Values: []user_config.TypeValue{{Value: "+"}}},
+			wantErr: `value "+" of enum type "Sign" has no letters or digits for a constant name`,
+		},
+		{
+			name:    "same constant name",
+			td:      user_config.TypeDefinition{Name: "Region", Type: "string", Kind: "enum", Values: []user_config.TypeValue{{Value: "eu-west"}, {Value: "eu_west"}}},
+			wantErr: `values "eu-west" and "eu_west" of enum type "Region" have the same constant name RegionEuWest`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.td.Validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, tt.td.IsEnum())
+		})
+	}
+}
+
+func TestTypeDefinition_ConstName(t *testing.T) {
+	t.Parallel()
+
+	td := user_config.TypeDefinition{Name: "LogLevel", Type: "string", Kind: "enum"}
+
+	require.Equal(t, "LogLevelDebug", td.ConstName("debug"))
+	require.Equal(t, "LogLevelWarn", td.ConstName("WARN"))
+	require.Equal(t, "LogLevelEuWest1", td.ConstName("eu-west-1"))
+}
+
+func TestTypeValue(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.yaml": `types:
+  - name: Mode
+    type: string
+    values:
+      - fast
+      - value: safe
+        description: Checks every step
+      - value: legacy
+        deprecated: true
+      - value: old
+        description: Old mode
+        deprecated: true
+groups:
+  - name: App
+    fields:
+      - name: Mode
+        type: Mode
+        default: safe
+`})
+
+	cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	td := cfg.FindType("Mode")
+	require.NotNil(t, td)
+	require.Equal(t, []string{"fast", "safe", "legacy", "old"}, td.ValueNames())
+	require.True(t, td.HasValue("legacy"))
+	require.False(t, td.HasValue("slow"))
+	require.True(t, td.HasValueDetails())
+
+	notes := make([]string, 0, len(td.Values))
+	for _, v := range td.Values {
+		notes = append(notes, v.Note())
+	}
+
+	require.Equal(t, []string{"", "Checks every step", "Deprecated", "Old mode (deprecated)"}, notes)
+	require.False(t, td.Values[0].HasDetails())
+	require.Equal(t, "safe", td.Values[1].String())
+}
+
+func TestTypeValue_RequiresValue(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{"config.yaml": `types:
+  - name: Mode
+    type: string
+    values:
+      - description: Missing value
+groups:
+  - name: App
+    fields:
+      - name: Mode
+        type: Mode
+`})
+
+	cfg, err := user_config.New(filepath.Join(tmpDir, "config.yaml"))
+	if err == nil {
+		err = cfg.Validate()
+	}
+
+	require.ErrorContains(t, err, `value of type "Mode" is required`)
+}
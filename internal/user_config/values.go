@@ -0,0 +1,177 @@
+package user_config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validateFieldValues checks that the expanded default and example values of a field, including
+// the default values of its profiles, can be parsed as the field type and satisfy its validation rules.
+// Returns a diagnostic for every invalid value.
+func (c *Config) validateFieldValues(group *Group, field *Field) Diagnostics {
+	var diags Diagnostics
+
+	type fieldValue struct {
+		name  string
+		value string
+		pos   Position
+	}
+
+	values := []fieldValue{
+		{name: "default", value: field.ExpandedDefault(), pos: field.pos.at("default")},
+		{name: "example", value: field.ExpandedExample(), pos: field.pos.at("example")},
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(field.Profiles)) {
+		if profile := field.Profiles[name]; profile != nil && profile.Default != nil {
+			values = append(values, fieldValue{
+				name:  fmt.Sprintf("profile %q default", name),
+				value: *profile.Default,
+				pos:   profile.pos.at("default"),
+			})
+		}
+	}
+
+	for _, v := range values {
+		// Values with unresolved references or references to secret values cannot be checked
+		if v.value == "" || referencePattern.MatchString(v.value) {
+			continue
+		}
+
+		if err := c.checkFieldValue(field, v.value); err != nil {
+			diags = append(diags, diagnosticf(v.pos, "invalid %s value %q for field %q in group %q: %s",
+				v.name, v.value, field.Name, group.Name, err))
+		}
+	}
+
+	return diags
+}
+
+// checkFieldValue checks that the value can be parsed as the type of the field
+// and satisfies its validation rules.
+func (c *Config) checkFieldValue(field *Field, value string) error {
+	if err := c.checkValue(field, value); err != nil {
+		return err
+	}
+
+	return field.Constraints.Check(value, TypeKind(c.ResolveType(field.Type)), field.GetSeparator())
+}
+
+// checkValue checks that the value can be parsed as the type of the field.
+// Values of custom types must be one of the values listed in the type definition,
+// list and map values are split with the separators of the field.
+func (c *Config) checkValue(field *Field, value string) error {
+	return c.parseValue(field.Type, value, field.separators())
+}
+
+// parseValue checks that the value can be parsed as the specified type. Custom types,
+// including the element and key types of lists and maps, are resolved to their definitions
+// and their values must be one of the listed values. Values of types unknown to envgen are accepted as is.
+func (c *Config) parseValue(typeName, value string, sep separators) error {
+	goType := typeName
+
+	if t := c.FindType(typeName); t != nil {
+		if t.HasValues() && !t.HasValue(value) {
+			return fmt.Errorf("expected one of %s", strings.Join(t.ValueNames(), ", "))
+		}
+
+		goType = t.Type
+	}
+
+	var err error
+
+	switch TypeKind(goType) {
+	case KindInt:
+		_, err = strconv.ParseInt(value, 10, bitSize(goType))
+	case KindUint:
+		_, err = strconv.ParseUint(value, 10, bitSize(goType))
+	case KindFloat:
+		_, err = strconv.ParseFloat(value, bitSize(goType))
+	case KindBool:
+		_, err = strconv.ParseBool(value)
+	case KindDuration:
+		_, err = time.ParseDuration(value)
+	case KindURL:
+		_, err = url.Parse(value)
+	case KindIP:
+		if net.ParseIP(value) == nil {
+			err = errors.New("invalid IP address")
+		}
+	case KindByteSize:
+		err = parseByteSize(value)
+	case KindSlice:
+		for _, elem := range strings.Split(value, sep.list) {
+			if err := c.parseValue(ElemType(goType), elem, sep); err != nil {
+				return fmt.Errorf("invalid list element %q: %w", elem, err)
+			}
+		}
+
+		return nil
+	case KindMap:
+		for _, entry := range strings.Split(value, sep.list) {
+			key, elem, ok := strings.Cut(entry, sep.keyValue)
+			if !ok {
+				return fmt.Errorf("invalid map entry %q: expected key%svalue", entry, sep.keyValue)
+			}
+
+			if err := c.parseValue(KeyType(goType), key, sep); err != nil {
+				return fmt.Errorf("invalid map key %q: %w", key, err)
+			}
+
+			if err := c.parseValue(ElemType(goType), elem, sep); err != nil {
+				return fmt.Errorf("invalid map value %q: %w", elem, err)
+			}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("expected %s", goType)
+	}
+
+	return nil
+}
+
+// bitSize returns the size in bits of a numeric Go type.
+// Returns 0 for types without an explicit size (int, uint).
+func bitSize(goType string) int {
+	switch goType {
+	case "int8", "uint8", "byte":
+		return 8
+	case "int16", "uint16":
+		return 16
+	case "int32", "uint32", "rune", "float32":
+		return 32
+	default:
+		if strings.HasSuffix(goType, "64") {
+			return 64
+		}
+
+		return 0
+	}
+}
+
+// byteSizeUnits lists the units of byte sizes accepted by datasize.ByteSize, in lower case.
+var byteSizeUnits = []string{"", "b", "k", "kb", "m", "mb", "g", "gb", "t", "tb", "p", "pb", "e", "eb"}
+
+// parseByteSize checks that the value is a whole number of bytes with an optional unit, e.g. 512KB or 10 MB.
+func parseByteSize(value string) error {
+	digits := strings.TrimRightFunc(value, func(r rune) bool { return r < '0' || r > '9' })
+	unit := strings.ToLower(strings.TrimSpace(value[len(digits):]))
+
+	if _, err := strconv.ParseUint(digits, 10, 64); err != nil || !slices.Contains(byteSizeUnits, unit) {
+		return errors.New("invalid byte size")
+	}
+
+	return nil
+}
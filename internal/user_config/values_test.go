@@ -0,0 +1,152 @@
+package user_config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
+
+func TestConfigValidateValues(t *testing.T) {
+	t.Parallel()
+
+	types := []user_config.TypeDefinition{
+		{Name: "LogLevel", Type: "string", Values: []user_config.TypeValue{{Value: "debug"}, {Value: "info"}}},
+		{Name: "Duration", Type: "time.Duration"},
+	}
+
+	tests := []struct {
+		name    string
+		field   user_config.Field
+		wantErr []string
+	}{
+		{name: "valid int", field: user_config.Field{Name: "Port", Type: "int", Default: "8080"}},
+		{
+			name:    "invalid int",
+			field:   user_config.Field{Name: "Port", Type: "int", Default: "eighty"},
+			wantErr: []string{`invalid default value "eighty" for field "Port" in group "App": expected int`},
+		},
+		{
+			name:    "int overflow",
+			field:   user_config.Field{Name: "Level", Type: "int8", Example: "300"},
+			wantErr: []string{`invalid example value "300" for field "Level" in group "App": expected int8`},
+		},
+		{
+			name:    "negative uint",
+			field:   user_config.Field{Name: "Workers", Type: "uint", Default: "-1"},
+			wantErr: []string{"expected uint"},
+		},
+		{name: "valid float", field: user_config.Field{Name: "Ratio", Type: "float64", Default: "0.5"}},
+		{
+			name:    "invalid bool",
+			field:   user_config.Field{Name: "Debug", Type: "bool", Default: "yes"},
+			wantErr: []string{"expected bool"},
+		},
+		{name: "valid duration", field: user_config.Field{Name: "Timeout", Type: "Duration", Default: "30s"}},
+		{
+			name:    "invalid duration",
+			field:   user_config.Field{Name: "Timeout", Type: "time.Duration", Example: "30"},
+			wantErr: []string{"expected time.Duration"},
+		},
+		{
+			name:    "invalid url",
+			field:   user_config.Field{Name: "API", Type: "*url.URL", Default: "http://[::1"},
+			wantErr: []string{"expected *url.URL"},
+		},
+		{name: "valid ip", field: user_config.Field{Name: "Bind", Type: "net.IP", Default: "::1"}},
+		{
+			name:    "invalid ip",
+			field:   user_config.Field{Name: "Bind", Type: "net.IP", Default: "10.0.0.256"},
+			wantErr: []string{"expected net.IP"},
+		},
+		{name: "valid byte size", field: user_config.Field{Name: "Limit", Type: "datasize.ByteSize", Default: "10 mb"}},
+		{
+			name:    "invalid byte size",
+			field:   user_config.Field{Name: "Limit", Type: "datasize.ByteSize", Default: "1.5GB"},
+			wantErr: []string{"expected datasize.ByteSize"},
+		},
+		{name: "library type", field: user_config.Field{Name: "Bind", Type: "IP", Example: "10.0.0.1"}},
+		{name: "valid list", field: user_config.Field{Name: "Ports", Type: "[]int", Example: "80,443"}},
+		{
+			name:    "invalid list element",
+			field:   user_config.Field{Name: "Ports", Type: "[]int", Example: "80,https"},
+			wantErr: []string{`invalid list element "https": expected int`},
+		},
+		{name: "valid enum", field: user_config.Field{Name: "Level", Type: "LogLevel", Default: "info"}},
+		{
+			name:    "invalid enum",
+			field:   user_config.Field{Name: "Level", Type: "LogLevel", Default: "trace"},
+			wantErr: []string{"expected one of debug, info"},
+		},
+		{name: "custom type", field: user_config.Field{Name: "Level", Type: "zerolog.Level", Default: "anything"}},
+		{name: "valid list of custom type", field: user_config.Field{Name: "Timeouts", Type: "[]Duration", Example: "1s,5m"}},
+		{
+			name:    "invalid list element of custom type",
+			field:   user_config.Field{Name: "Timeouts", Type: "[]Duration", Example: "1s,5"},
+			wantErr: []string{`invalid list element "5": expected time.Duration`},
+		},
+		{
+			name:  "valid map of custom type",
+			field: user_config.Field{Name: "Levels", Type: "map[string]LogLevel", Default: "api:debug,db:info"},
+		},
+		{
+			name:    "invalid map value of custom type",
+			field:   user_config.Field{Name: "Levels", Type: "map[string]LogLevel", Default: "api:debug,db:trace"},
+			wantErr: []string{`invalid map value "trace": expected one of debug, info`},
+		},
+		{
+			name:    "invalid map key of custom type",
+			field:   user_config.Field{Name: "Levels", Type: "map[LogLevel]int", Example: "trace:1"},
+			wantErr: []string{`invalid map key "trace": expected one of debug, info`},
+		},
+		{
+			name: "violates validation rules",
+			field: user_config.Field{
+				Name:        "Port",
+				Type:        "int",
+				Default:     "0",
+				Example:     "70000",
+				Constraints: &user_config.Constraints{Min: ptr(1.0), Max: ptr(65535.0)},
+			},
+			wantErr: []string{
+				`invalid default value "0" for field "Port" in group "App": must be at least 1`,
+				`invalid example value "70000" for field "Port" in group "App": must be at most 65535`,
+			},
+		},
+		{
+			name: "violates format",
+			field: user_config.Field{
+				Name:        "Host",
+				Type:        "string",
+				Default:     "not a host",
+				Constraints: &user_config.Constraints{Format: "hostname"},
+			},
+			wantErr: []string{"must be a valid hostname"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &user_config.Config{
+				Types: types,
+				Groups: []user_config.Group{
+					{Name: "App", Fields: []user_config.Field{tt.field}},
+				},
+			}
+
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			for _, want := range tt.wantErr {
+				require.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package envgen
 import (
 	"context"
 	"fmt"
+	"log"
 	"text/template"
 
 	"github.com/safeblock-dev/envgen/internal/user_config"
@@ -47,7 +48,18 @@ func New(ctx context.Context, opts Options) (*Envgen, error) {
 // SetConfig sets the configuration for code generation.
 func (e *Envgen) SetConfig(opts Options) error {
 	// Read and parse configuration
-	cfg, err := user_config.New(opts.ConfigPath)
+	loadOpts := user_config.LoadOptions{AllowUnknownKeys: opts.AllowUnknownKeys}
+
+	if opts.ConfigFormat != "" {
+		format, err := user_config.ParseFileFormat(opts.ConfigFormat)
+		if err != nil {
+			return err
+		}
+
+		loadOpts.Format = format
+	}
+
+	cfg, err := user_config.NewWithOptions(opts.ConfigPath, loadOpts)
 	if err != nil {
 		return err
 	}
@@ -57,9 +69,23 @@ func (e *Envgen) SetConfig(opts Options) error {
 		return err
 	}
 
-	// Filter out ignored types and groups
+	// Resolve values of the selected profile
+	if opts.Profile != "" {
+		if err := cfg.ApplyProfile(opts.Profile); err != nil {
+			return err
+		}
+	}
+
+	// Filter out ignored types, groups and fields
 	cfg.FilterTypes(opts.IgnoreTypes)
+	cfg.SelectGroups(opts.OnlyGroups)
 	cfg.FilterGroups(opts.IgnoreGroups)
+	cfg.FilterTags(opts.IncludeTags, opts.ExcludeTags)
+	cfg.FilterFields(opts.IgnoreFields)
+
+	for _, warning := range cfg.Warnings() {
+		log.Println("warning:", warning)
+	}
 
 	e.userConfig = cfg
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/safeblock-dev/envgen/internal/template_funcs"
+	"github.com/safeblock-dev/envgen/internal/user_config"
 )
 
 // Funcs returns a map of functions available in templates.
@@ -33,12 +34,14 @@ func (e *Envgen) Funcs() template.FuncMap {
 		"pascal": template_funcs.ToPascalCase,
 		"append": template_funcs.StringAppend,
 		"uniq":   template_funcs.StringUniq,
+		"sort":   template_funcs.StringSort,
 		"slice":  template_funcs.StringSlice,
 
 		// Type conversions
 		"toString": template_funcs.ToString,
 		"toInt":    template_funcs.ToInt,
 		"toBool":   template_funcs.ToBool,
+		"toList":   template_funcs.ToList,
 
 		// Date and time functions
 		"now":        time.Now,
@@ -92,11 +95,33 @@ func (e *Envgen) Funcs() template.FuncMap {
 		"hasGroupOption":  e.userConfig.HasGroupOption,
 		"getOption":       e.userConfig.GetOption,
 		"getGroupOption":  e.userConfig.GetGroupOption,
+		"groupOption":     e.userConfig.GroupOption,
+		"fieldOption":     e.userConfig.FieldOption,
 		"processTemplate": e.ProcessTemplate,
 
 		// Type helpers
-		"findType":   e.userConfig.FindType,
-		"getImports": e.userConfig.GetImports,
+		"findType":    e.userConfig.FindType,
+		"getImports":  e.userConfig.GetImports,
+		"resolveType": e.userConfig.ResolveType,
+		"goType":      e.userConfig.GoType,
+		"typeKind":    user_config.TypeKind,
+
+		// Group helpers
+		"findGroup":           e.userConfig.FindGroup,
+		"variables":           e.userConfig.Variables,
+		"isNested":            e.userConfig.IsNested,
+		"hasRenamedVariables": e.userConfig.HasRenamedVariables,
+
+		// Profile helpers
+		"profileValues": e.userConfig.ProfileValues,
+		"getProfile":    e.userConfig.GetProfile,
+
+		// Conditional requirement helpers
+		"conditions":               e.userConfig.Conditions,
+		"hasEnforceableConditions": e.userConfig.HasEnforceableConditions,
+
+		// Collection helpers
+		"separatorNote": e.userConfig.SeparatorNote,
 	}
 }
 
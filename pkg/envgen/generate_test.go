@@ -53,4 +53,52 @@ func TestEnvgen_Generate(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "package main\n", string(result))
 	})
+
+	t.Run("profile", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+
+		configPath := filepath.Join(tmpDir, "config.yaml")
+		outputPath := filepath.Join(tmpDir, "output.env")
+		templatePath := filepath.Join(tmpDir, "template.tmpl")
+
+		configContent := `profiles: [dev, prod]
+groups:
+  - name: App
+    fields:
+      - name: ssl
+        type: bool
+        default: "false"
+        profiles:
+          prod:
+            default: "true"
+            required: true`
+		err := os.WriteFile(configPath, []byte(configContent), 0o600)
+		require.NoError(t, err)
+
+		templateContent := `{{ getProfile }}{{ range .Groups }}{{ range .Fields }}:{{ .Default }}:{{ .Required }}{{ end }}{{ end }}`
+		err = os.WriteFile(templatePath, []byte(templateContent), 0o600)
+		require.NoError(t, err)
+
+		err = envgen.Generate(t.Context(), envgen.Options{
+			ConfigPath:   configPath,
+			OutputPath:   outputPath,
+			TemplatePath: templatePath,
+			Profile:      "prod",
+		})
+		require.NoError(t, err)
+
+		result, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		require.Equal(t, "prod:true:true", string(result))
+
+		err = envgen.Generate(t.Context(), envgen.Options{
+			ConfigPath:   configPath,
+			OutputPath:   outputPath,
+			TemplatePath: templatePath,
+			Profile:      "staging",
+		})
+		require.ErrorContains(t, err, `unknown profile "staging"`)
+	})
 }
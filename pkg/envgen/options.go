@@ -1,19 +1,37 @@
 package envgen
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/safeblock-dev/envgen/internal/user_config"
+)
 
 // Options contains options for the Generate function.
 type Options struct {
-	// ConfigPath is the path to the YAML configuration file
+	// ConfigPath is the path to the YAML, JSON or TOML configuration file
 	ConfigPath string
+	// ConfigFormat overrides the configuration format detected by the file extension (yaml, json, toml)
+	ConfigFormat string
 	// OutputPath is the path where the generated file will be written
 	OutputPath string
 	// TemplatePath is the path to the template file, URL, or standard template name
 	TemplatePath string
-	// IgnoreTypes is a list of type names to ignore during generation
+	// IgnoreTypes is a list of type name glob patterns to ignore during generation
 	IgnoreTypes []string
-	// IgnoreGroups is a list of group names to ignore during generation
+	// IgnoreGroups is a list of group name glob patterns to ignore during generation
 	IgnoreGroups []string
+	// OnlyGroups is a list of group name glob patterns to generate, together with the groups they nest
+	OnlyGroups []string
+	// IgnoreFields is a list of Group.Field glob patterns to ignore during generation
+	IgnoreFields []string
+	// IncludeTags is a list of tag glob patterns of the fields to generate
+	IncludeTags []string
+	// ExcludeTags is a list of tag glob patterns of the fields to ignore during generation
+	ExcludeTags []string
+	// Profile is the name of the profile whose default values and required flags are used
+	Profile string
+	// AllowUnknownKeys disables the rejection of unknown keys in the configuration
+	AllowUnknownKeys bool
 }
 
 // Validate checks if all required options are set.
@@ -22,6 +40,12 @@ func (opts *Options) Validate() error {
 		return errors.New("config path is required")
 	}
 
+	if opts.ConfigFormat != "" {
+		if _, err := user_config.ParseFileFormat(opts.ConfigFormat); err != nil {
+			return err
+		}
+	}
+
 	if opts.OutputPath == "" {
 		return errors.New("output path is required")
 	}
@@ -30,5 +54,15 @@ func (opts *Options) Validate() error {
 		return errors.New("template path is required")
 	}
 
+	for _, patterns := range [][]string{opts.IgnoreTypes, opts.IgnoreGroups, opts.OnlyGroups, opts.IncludeTags, opts.ExcludeTags} {
+		if err := user_config.CheckPatterns(patterns); err != nil {
+			return err
+		}
+	}
+
+	if err := user_config.CheckFieldPatterns(opts.IgnoreFields); err != nil {
+		return err
+	}
+
 	return nil
 }
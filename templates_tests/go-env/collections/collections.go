@@ -0,0 +1,26 @@
+// Code generated by envgen. DO NOT EDIT.
+// This file was automatically generated and should not be modified manually.
+
+//go:generate envgen gen -c ../collections.yaml -o collections.generated -t ../../../templates/go-env
+
+package collections
+import (
+	"fmt"
+)
+
+// Server HTTP server settings
+type Server struct {
+	AllowedOrigins []string `env:"SERVER_ALLOWED_ORIGINS" envDefault:"http://localhost:3000,http://localhost:8080"` // Allowed CORS origins
+	Ports []int `env:"SERVER_PORTS" envDefault:"8080;8443" envSeparator:";"` // Listening ports
+	Headers map[string]string `env:"SERVER_HEADERS" envSeparator:";" envKeyValSeparator:"="` // Extra response headers
+	Weights map[string]float64 `env:"SERVER_WEIGHTS" envSeparator:"|"` // Backend weights
+}
+
+// Validate checks the validation rules declared for Server.
+func (c Server) Validate() error {
+	if len(c.Ports) > 4 {
+		return fmt.Errorf("SERVER_PORTS must have length at most 4, got %d", len(c.Ports))
+	}
+
+	return nil
+}
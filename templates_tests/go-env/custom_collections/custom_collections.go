@@ -0,0 +1,54 @@
+// Code generated by envgen. DO NOT EDIT.
+// This file was automatically generated and should not be modified manually.
+
+//go:generate envgen gen -c ../custom_collections.yaml -o custom_collections.generated -t ../../../templates/go-env
+
+package custom_collections
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Level
+type Level string
+
+// Values of Level.
+const (
+	LevelDebug Level = "debug"
+	LevelInfo Level = "info"
+)
+
+// String returns the value of the Level.
+func (v Level) String() string {
+	return string(v)
+}
+
+// IsValid reports whether the value is one of the Level values.
+func (v Level) IsValid() bool {
+	switch v {
+	case LevelDebug, LevelInfo:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnmarshalText decodes the Level from the text and rejects unknown values.
+func (v *Level) UnmarshalText(text []byte) error {
+	value := Level(text)
+	if !value.IsValid() {
+		return fmt.Errorf("invalid Level %q, expected one of %s", text, "debug, info")
+	}
+
+	*v = value
+
+	return nil
+}
+
+// Client HTTP client settings
+type Client struct {
+	Timeouts []time.Duration `env:"CLIENT_TIMEOUTS" envDefault:"1s,5s,30s"` // Timeouts of the retries
+	Endpoints map[string]url.URL `env:"CLIENT_ENDPOINTS"` // Endpoints by region
+	Levels map[string]Level `env:"CLIENT_LEVELS" envDefault:"http:info,db:debug"` // Log levels by component
+}
@@ -0,0 +1,23 @@
+// Code generated by envgen. DO NOT EDIT.
+// This file was automatically generated and should not be modified manually.
+
+//go:generate envgen gen -c ../include.yaml -o include.generated -t ../../../templates/go-env
+
+package include
+
+// PostgresConfig Configuration for PostgreSQL database connection
+type PostgresConfig struct {
+	URL string `env:"POSTGRES_URL,required,notEmpty"` // PostgreSQL connection URL
+}
+
+// RedisConfig Configuration for Redis database connection
+type RedisConfig struct {
+	URL string `env:"REDIS_URL,required,notEmpty"` // Redis connection URL
+}
+
+// App Application settings
+type App struct {
+	Postgres PostgresConfig // Shared PostgreSQL settings
+	Redis RedisConfig // Shared Redis settings
+	Port int `env:"PORT" envDefault:"8080"` // Server port
+}
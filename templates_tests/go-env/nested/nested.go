@@ -0,0 +1,31 @@
+// Code generated by envgen. DO NOT EDIT.
+// This file was automatically generated and should not be modified manually.
+
+//go:generate envgen gen -c ../nested.yaml -o nested.generated -t ../../../templates/go-env
+
+package nested
+
+// Webserver Web server settings
+type Webserver struct {
+	Port int `env:"WEBSERVER_PORT" envDefault:"8080"` // HTTP port
+	Health HealthConfig `envPrefix:"WEBSERVER_"` // Health check settings
+	Metrics Metrics `envPrefix:"WEBSERVER_"` // Metrics of the web server
+}
+
+// Worker Background worker settings
+type Worker struct {
+	Concurrency int `env:"CONCURRENCY" envDefault:"4"` // Number of parallel jobs
+	Health HealthConfig // Health check settings
+}
+
+// HealthConfig Health check settings
+type HealthConfig struct {
+	Port int `env:"HEALTH_PORT" envDefault:"8081"` // Health check port
+	Path string `env:"HEALTH_PATH" envDefault:"/healthz"` // Health check path
+}
+
+// Metrics Metrics settings
+type Metrics struct {
+	Enabled bool `env:"METRICS_ENABLED" envDefault:"true"` // Enable metrics
+	Health HealthConfig `envPrefix:"METRICS_"` // Health check settings
+}
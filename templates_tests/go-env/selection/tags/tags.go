@@ -0,0 +1,27 @@
+// Code generated by envgen. DO NOT EDIT.
+// This file was automatically generated and should not be modified manually.
+
+//go:generate envgen gen -c ../../selection.yaml -o tags.generated -t ../../../../templates/go-env
+
+package config
+import (
+	"time"
+)
+
+// App Application settings
+type App struct {
+	Env string `env:"APP_ENV" envDefault:"development"` // Application environment
+}
+
+// Worker Background worker settings
+type Worker struct {
+	Concurrency int `env:"WORKER_CONCURRENCY" envDefault:"4"` // Number of parallel jobs
+	PollInterval time.Duration `env:"WORKER_POLL_INTERVAL" envDefault:"1s"` // Queue polling interval
+	Health HealthConfig `envPrefix:"WORKER_"` // Health check settings
+}
+
+// HealthConfig Health check settings
+type HealthConfig struct {
+	Port int `env:"HEALTH_PORT" envDefault:"8081"` // Health check port
+	Verbose bool `env:"HEALTH_VERBOSE"` // Log health checks
+}
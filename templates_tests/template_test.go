@@ -19,6 +19,11 @@ type testCase struct {
 	outputFile   string
 	ignoreTypes  []string
 	ignoreGroups []string
+	onlyGroups   []string
+	ignoreFields []string
+	includeTags  []string
+	excludeTags  []string
+	profile      string
 	fromURL      bool
 }
 
@@ -41,6 +46,13 @@ func TestTemplates(t *testing.T) {
 			template:   "../templates/example",
 			outputFile: "example/basic.generated",
 		},
+		{
+			name:       "example/value_descriptions",
+			configFile: "example/value_descriptions.yaml",
+			goldenFile: "example/value_descriptions.env",
+			template:   "../templates/example",
+			outputFile: "example/value_descriptions.generated",
+		},
 		{
 			name:       "example/minimal",
 			configFile: "example/minimal.yaml",
@@ -55,6 +67,13 @@ func TestTemplates(t *testing.T) {
 			template:   "../templates/example",
 			outputFile: "example/types.generated",
 		},
+		{
+			name:       "example/library_types",
+			configFile: "example/library_types.yaml",
+			goldenFile: "example/library_types.env",
+			template:   "../templates/example",
+			outputFile: "example/library_types.generated",
+		},
 		{
 			name:       "example/prefix",
 			configFile: "example/prefix.yaml",
@@ -62,6 +81,63 @@ func TestTemplates(t *testing.T) {
 			template:   "../templates/example",
 			outputFile: "example/prefix.generated",
 		},
+		{
+			name:       "example/validate",
+			configFile: "example/validate.yaml",
+			goldenFile: "example/validate.env",
+			template:   "../templates/example",
+			outputFile: "example/validate.generated",
+		},
+		{
+			name:       "example/secret",
+			configFile: "example/secret.yaml",
+			goldenFile: "example/secret.env",
+			template:   "../templates/example",
+			outputFile: "example/secret.generated",
+		},
+		{
+			name:       "example/deprecated",
+			configFile: "example/deprecated.yaml",
+			goldenFile: "example/deprecated.env",
+			template:   "../templates/example",
+			outputFile: "example/deprecated.generated",
+		},
+		{
+			name:       "example/nested",
+			configFile: "example/nested.yaml",
+			goldenFile: "example/nested.env",
+			template:   "../templates/example",
+			outputFile: "example/nested.generated",
+		},
+		{
+			name:       "example/interpolation",
+			configFile: "example/interpolation.yaml",
+			goldenFile: "example/interpolation.env",
+			template:   "../templates/example",
+			outputFile: "example/interpolation.generated",
+		},
+		{
+			name:       "example/conditional",
+			configFile: "example/conditional.yaml",
+			goldenFile: "example/conditional.env",
+			template:   "../templates/example",
+			outputFile: "example/conditional.generated",
+		},
+		{
+			name:       "example/collections",
+			configFile: "example/collections.yaml",
+			goldenFile: "example/collections.env",
+			template:   "../templates/example",
+			outputFile: "example/collections.generated",
+		},
+		{
+			name:       "example/profiles",
+			configFile: "example/profiles.yaml",
+			goldenFile: "example/profiles.env",
+			template:   "../templates/example",
+			outputFile: "example/profiles.generated",
+			profile:    "prod",
+		},
 		{
 			name:         "example/ignore-types",
 			configFile:   "example/ignore.yaml",
@@ -89,6 +165,15 @@ func TestTemplates(t *testing.T) {
 			ignoreTypes:  []string{"Duration"},
 			ignoreGroups: []string{"App"},
 		},
+		{
+			name:        "example/selection-tags",
+			configFile:  "example/selection.yaml",
+			goldenFile:  "example/selection-tags.env",
+			template:    "../templates/example",
+			outputFile:  "example/selection-tags.generated",
+			includeTags: []string{"worker"},
+			excludeTags: []string{"internal"},
+		},
 
 		// --------------------------------
 		// Go-env template tests (Go structs)
@@ -163,6 +248,25 @@ func TestTemplates(t *testing.T) {
 			ignoreTypes:  []string{"Duration"},
 			ignoreGroups: []string{"App"},
 		},
+		{
+			name:         "go-env/selection-tags",
+			configFile:   "go-env/selection.yaml",
+			goldenFile:   "go-env/selection/tags/tags.go",
+			template:     "../templates/go-env",
+			outputFile:   "go-env/selection/tags/tags.generated",
+			includeTags:  []string{"worker"},
+			excludeTags:  []string{"*-api"},
+			ignoreFields: []string{"App.Debug"},
+		},
+		{
+			name:         "go-env/selection-groups",
+			configFile:   "go-env/selection.yaml",
+			goldenFile:   "go-env/selection/groups/groups.go",
+			template:     "../templates/go-env",
+			outputFile:   "go-env/selection/groups/groups.generated",
+			onlyGroups:   []string{"Serv*"},
+			ignoreFields: []string{"*.Verbose"},
+		},
 		{
 			name:       "go-env/tags",
 			configFile: "go-env/tags.yaml",
@@ -191,6 +295,105 @@ func TestTemplates(t *testing.T) {
 			goldenFile: "go-env/skip_env_tag/skip_env_tag.go",
 			outputFile: "go-env/skip_env_tag/skip_env_tag.generated",
 		},
+		{
+			name:       "go-env/option_inheritance",
+			configFile: "go-env/option_inheritance.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/option_inheritance/option_inheritance.go",
+			outputFile: "go-env/option_inheritance/option_inheritance.generated",
+		},
+		{
+			name:       "go-env/typed_options",
+			configFile: "go-env/typed_options.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/typed_options/typed_options.go",
+			outputFile: "go-env/typed_options/typed_options.generated",
+		},
+		{
+			name:       "go-env/enum",
+			configFile: "go-env/enum.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/enum/enum.go",
+			outputFile: "go-env/enum/enum.generated",
+		},
+		{
+			name:       "go-env/library_types",
+			configFile: "go-env/library_types.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/library_types/library_types.go",
+			outputFile: "go-env/library_types/library_types.generated",
+		},
+		{
+			name:       "go-env/include",
+			configFile: "go-env/include.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/include/include.go",
+			outputFile: "go-env/include/include.generated",
+		},
+		{
+			name:       "go-env/validate",
+			configFile: "go-env/validate.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/validate/validate.go",
+			outputFile: "go-env/validate/validate.generated",
+		},
+		{
+			name:       "go-env/secret",
+			configFile: "go-env/secret.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/secret/secret.go",
+			outputFile: "go-env/secret/secret.generated",
+		},
+		{
+			name:       "go-env/deprecated",
+			configFile: "go-env/deprecated.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/deprecated/deprecated.go",
+			outputFile: "go-env/deprecated/deprecated.generated",
+		},
+		{
+			name:       "go-env/nested",
+			configFile: "go-env/nested.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/nested/nested.go",
+			outputFile: "go-env/nested/nested.generated",
+		},
+		{
+			name:       "go-env/interpolation",
+			configFile: "go-env/interpolation.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/interpolation/interpolation.go",
+			outputFile: "go-env/interpolation/interpolation.generated",
+		},
+		{
+			name:       "go-env/conditional",
+			configFile: "go-env/conditional.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/conditional/conditional.go",
+			outputFile: "go-env/conditional/conditional.generated",
+		},
+		{
+			name:       "go-env/collections",
+			configFile: "go-env/collections.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/collections/collections.go",
+			outputFile: "go-env/collections/collections.generated",
+		},
+		{
+			name:       "go-env/custom_collections",
+			configFile: "go-env/custom_collections.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/custom_collections/custom_collections.go",
+			outputFile: "go-env/custom_collections/custom_collections.generated",
+		},
+		{
+			name:       "go-env/profiles",
+			configFile: "go-env/profiles.yaml",
+			template:   "../templates/go-env",
+			goldenFile: "go-env/profiles/profiles.go",
+			outputFile: "go-env/profiles/profiles.generated",
+			profile:    "prod",
+		},
 
 		// --------------------------------
 		// Markdown template tests (Documentation)
@@ -237,6 +440,83 @@ func TestTemplates(t *testing.T) {
 			goldenFile: "markdown/column_visibility.md",
 			outputFile: "markdown/column_visibility.generated",
 		},
+		{
+			name:       "markdown/option_inheritance",
+			configFile: "markdown/option_inheritance.yaml",
+			template:   "../templates/markdown",
+			goldenFile: "markdown/option_inheritance.md",
+			outputFile: "markdown/option_inheritance.generated",
+		},
+		{
+			name:       "markdown/value_descriptions",
+			configFile: "markdown/value_descriptions.yaml",
+			template:   "../templates/markdown",
+			goldenFile: "markdown/value_descriptions.md",
+			outputFile: "markdown/value_descriptions.generated",
+		},
+		{
+			name:       "markdown/library_types",
+			configFile: "markdown/library_types.yaml",
+			template:   "../templates/markdown",
+			goldenFile: "markdown/library_types.md",
+			outputFile: "markdown/library_types.generated",
+		},
+		{
+			name:       "markdown/validate",
+			configFile: "markdown/validate.yaml",
+			goldenFile: "markdown/validate.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/validate.generated",
+		},
+		{
+			name:       "markdown/secret",
+			configFile: "markdown/secret.yaml",
+			goldenFile: "markdown/secret.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/secret.generated",
+		},
+		{
+			name:       "markdown/deprecated",
+			configFile: "markdown/deprecated.yaml",
+			goldenFile: "markdown/deprecated.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/deprecated.generated",
+		},
+		{
+			name:       "markdown/nested",
+			configFile: "markdown/nested.yaml",
+			goldenFile: "markdown/nested.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/nested.generated",
+		},
+		{
+			name:       "markdown/interpolation",
+			configFile: "markdown/interpolation.yaml",
+			goldenFile: "markdown/interpolation.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/interpolation.generated",
+		},
+		{
+			name:       "markdown/conditional",
+			configFile: "markdown/conditional.yaml",
+			goldenFile: "markdown/conditional.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/conditional.generated",
+		},
+		{
+			name:       "markdown/collections",
+			configFile: "markdown/collections.yaml",
+			goldenFile: "markdown/collections.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/collections.generated",
+		},
+		{
+			name:       "markdown/profiles",
+			configFile: "markdown/profiles.yaml",
+			goldenFile: "markdown/profiles.md",
+			template:   "../templates/markdown",
+			outputFile: "markdown/profiles.generated",
+		},
 		{
 			name:         "markdown/ignore-types",
 			configFile:   "markdown/ignore.yaml",
@@ -303,6 +583,55 @@ func TestTemplates(t *testing.T) {
 			goldenFile: "go-env-example/types.env",
 			outputFile: "go-env-example/types.generated",
 		},
+		{
+			name:       "go-env-example/validate",
+			configFile: "go-env-example/validate.yaml",
+			template:   "../templates/go-env-example",
+			goldenFile: "go-env-example/validate.env",
+			outputFile: "go-env-example/validate.generated",
+		},
+		{
+			name:       "go-env-example/secret",
+			configFile: "go-env-example/secret.yaml",
+			template:   "../templates/go-env-example",
+			goldenFile: "go-env-example/secret.env",
+			outputFile: "go-env-example/secret.generated",
+		},
+		{
+			name:       "go-env-example/deprecated",
+			configFile: "go-env-example/deprecated.yaml",
+			template:   "../templates/go-env-example",
+			goldenFile: "go-env-example/deprecated.env",
+			outputFile: "go-env-example/deprecated.generated",
+		},
+		{
+			name:       "go-env-example/nested",
+			configFile: "go-env-example/nested.yaml",
+			template:   "../templates/go-env-example",
+			goldenFile: "go-env-example/nested.env",
+			outputFile: "go-env-example/nested.generated",
+		},
+		{
+			name:       "go-env-example/interpolation",
+			configFile: "go-env-example/interpolation.yaml",
+			template:   "../templates/go-env-example",
+			goldenFile: "go-env-example/interpolation.env",
+			outputFile: "go-env-example/interpolation.generated",
+		},
+		{
+			name:       "go-env-example/conditional",
+			configFile: "go-env-example/conditional.yaml",
+			template:   "../templates/go-env-example",
+			goldenFile: "go-env-example/conditional.env",
+			outputFile: "go-env-example/conditional.generated",
+		},
+		{
+			name:       "go-env-example/collections",
+			configFile: "go-env-example/collections.yaml",
+			template:   "../templates/go-env-example",
+			goldenFile: "go-env-example/collections.env",
+			outputFile: "go-env-example/collections.generated",
+		},
 		{
 			name:         "go-env-example/ignore-types",
 			configFile:   "go-env-example/ignore.yaml",
@@ -350,6 +679,11 @@ func TestTemplates(t *testing.T) {
 				TemplatePath: tt.template,
 				IgnoreTypes:  tt.ignoreTypes,
 				IgnoreGroups: tt.ignoreGroups,
+				OnlyGroups:   tt.onlyGroups,
+				IgnoreFields: tt.ignoreFields,
+				IncludeTags:  tt.includeTags,
+				ExcludeTags:  tt.excludeTags,
+				Profile:      tt.profile,
 			})
 			require.NoError(t, err)
 